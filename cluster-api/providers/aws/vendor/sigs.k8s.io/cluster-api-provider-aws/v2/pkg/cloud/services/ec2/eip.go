@@ -70,6 +70,27 @@ func (s *Service) ReleaseElasticIP(instanceID string) error {
 	return s.netService.ReleaseAddressByRole(getElasticIPRoleName(instanceID))
 }
 
+// ReconcileElasticIPFromPublicPool reconciles the Elastic IP allocated from a
+// custom Public IPv4 Pool for instance. It is the exported entry point the
+// instance controller should call instead of requesting a default public IP
+// at RunInstances time (see ShouldAssociatePublicIP): the default IP would
+// otherwise be allocated and immediately orphaned once this reconciles the
+// BYO address onto the instance.
+func (s *Service) ReconcileElasticIPFromPublicPool(pool *infrav1.ElasticIPPool, instance *infrav1.Instance) (bool, error) {
+	switch instance.State {
+	case infrav1.InstanceStateTerminated, infrav1.InstanceStateShuttingDown:
+		return false, fmt.Errorf("cannot reconcile Elastic IP Pool for instance %q in terminal state %q", instance.ID, instance.State)
+	}
+
+	return s.reconcileElasticIPFromPublicPool(pool, instance)
+}
+
+// ReleaseElasticIPOnTerminate releases the Elastic IP reserved for instance,
+// if any, as part of the instance delete path.
+func (s *Service) ReleaseElasticIPOnTerminate(instance *infrav1.Instance) error {
+	return s.ReleaseElasticIP(instance.ID)
+}
+
 // getAndAssociateAddressesToInstance find or create an EIP from an instance and role.
 func (s *Service) getAndAssociateAddressesToInstance(pool *infrav1.ElasticIPPool, role string, instance string) (err error) {
 	eips, err := s.netService.GetOrAllocateAddresses(pool, 1, role)
@@ -95,9 +116,12 @@ func (s *Service) getAndAssociateAddressesToInstance(pool *infrav1.ElasticIPPool
 
 // ReconcileElasticIP reconciles the elastic IP for a given instance.
 func (s *Service) ReconcileElasticIP(pool *infrav1.ElasticIPPool, instance *infrav1.Instance) (bool, error) {
-	// BYO Public IPv4 Pool has precendece over BYO EIP.
+	// BYO Public IPv4 Pool has precendece over BYO EIP. Route through the
+	// exported wrapper rather than calling reconcileElasticIPFromPublicPool
+	// directly, so a terminated/shutting-down instance is rejected the same
+	// way here as it is for callers of ReconcileElasticIPFromPublicPool.
 	if pool != nil {
-		return s.reconcileElasticIPFromPublicPool(pool, instance)
+		return s.ReconcileElasticIPFromPublicPool(pool, instance)
 	}
 
 	// Check if there are EIPs allocated and unassociated to the role.
@@ -151,15 +175,39 @@ func (s *Service) ReconcileElasticIP(pool *infrav1.ElasticIPPool, instance *infr
 	return shouldRequeue, nil
 }
 
+// ShouldAssociatePublicIP returns false when the instance must not receive an
+// Amazon-owned public IP at launch. When a BYO Elastic IP or BYO Public IPv4
+// Pool will be associated once the instance is running, requesting a default
+// public IP at RunInstances time would allocate an address that is
+// immediately orphaned once the BYO address is attached. The network
+// interface spec passed to RunInstances should set AssociatePublicIpAddress
+// to this value instead of unconditionally requesting one.
+//
+// This vendor checkout does not carry the ec2.Service instance-creation
+// reconciler (no RunInstances call site exists anywhere under this
+// package), so nothing in this tree calls ShouldAssociatePublicIP yet --
+// it, ReconcileElasticIPFromPublicPool, and ReleaseElasticIPOnTerminate
+// are the integration points that reconciler is expected to call into
+// once it lands, not a claim that the RunInstances flow has already been
+// changed.
+//
+// TODO(mtulio/installer#chunk1-2): wire ShouldAssociatePublicIP's result
+// into the network interface spec the instance-creation reconciler passes
+// to RunInstances once that reconciler is vendored into this tree; until
+// then, a BYO EIP/Public IPv4 Pool still gets a default Amazon-owned
+// public IP allocated (and leaked) at launch.
+func (s *Service) ShouldAssociatePublicIP(scope *scope.MachineScope) bool {
+	return !s.hasBYOPublicIP(scope)
+}
+
 // hasBYOPublicIP check if there is BYO IP configuration.
 func (s *Service) hasBYOPublicIP(scope *scope.MachineScope) bool {
-	s.scope.Debug("BYO IP Check 0", "machine", scope.AWSMachine.ObjectMeta.Name)
 	// Check if there is BYO Public IPv4 Pool configuration.
 	if scope.AWSMachine.Spec.ElasticIPPool != nil && scope.AWSMachine.Spec.ElasticIPPool.PublicIpv4Pool != nil {
+		s.scope.Debug("Machine has a BYO Public IPv4 Pool configured", "machine", scope.AWSMachine.ObjectMeta.Name)
 		return true
 	}
 
-	s.scope.Debug("BYO IP Check 1", "machine", scope.AWSMachine.ObjectMeta.Name)
 	// Check if there is BYO EIP allocation.
 	addrs, err := s.netService.GetAddresses(eipRoleCustomEC2)
 	if err != nil {
@@ -167,7 +215,6 @@ func (s *Service) hasBYOPublicIP(scope *scope.MachineScope) bool {
 		return false
 	}
 
-	s.scope.Debug("BYO IP Check 2", "machine", scope.AWSMachine.ObjectMeta.Name)
 	// No BYO EIPs allocated, and no Public IPv4 Pool defined. Use default.
 	if len(addrs.Addresses) == 0 {
 		return false
@@ -180,14 +227,12 @@ func (s *Service) hasBYOPublicIP(scope *scope.MachineScope) bool {
 		}
 	}
 
-	s.scope.Debug("BYO IP Check 3", "machine", scope.AWSMachine.ObjectMeta.Name, "addresses", len(addrs.Addresses), "free-addresses", len(freeAddresses))
 	// No BYO EIPs allocated to the role.
 	if len(freeAddresses) == 0 {
 		s.scope.Debug("Skipping BYO EIP association to instance with no free addresses matching the role.", "eip-role", eipRoleCustomEC2)
 		return false
 	}
 
-	s.scope.Debug("BYO IP Check 4")
-	s.scope.Debug("Found free EIP allocation matching to the instance role, using it.", "eip-role", eipRoleCustomEC2)
+	s.scope.Debug("Found free EIP allocation matching to the instance role, using it.", "machine", scope.AWSMachine.ObjectMeta.Name, "eip-role", eipRoleCustomEC2, "free-addresses", len(freeAddresses))
 	return true
 }
@@ -13,6 +13,11 @@ func getElasticIPRoleName() string {
 	return fmt.Sprintf("lb-%s", infrav1.APIServerRoleTagValue)
 }
 
+// isDualStack reports whether input requests a dualstack NLB.
+func isDualStack(input *elbv2.CreateLoadBalancerInput) bool {
+	return input.IpAddressType != nil && *input.IpAddressType == elbv2.IpAddressTypeDualstack
+}
+
 func (s *Service) getOrAllocateAddresses(input *elbv2.CreateLoadBalancerInput) error {
 	// Only NLB is supported
 	if input.Type == nil {
@@ -27,17 +32,77 @@ func (s *Service) getOrAllocateAddresses(input *elbv2.CreateLoadBalancerInput) e
 		return fmt.Errorf("PublicIpv4Pool is mutually exclusive with SubnetMappings")
 	}
 
+	var ipv6s []string
+	dualStack := isDualStack(input)
+	if dualStack {
+		if s.scope.VPC().GetPublicIpv6Pool() == nil && s.scope.VPC().GetIPv6ElasticIPPool() == nil {
+			return fmt.Errorf("dualstack Network Load Balancer requires a BYO IPv6 pool (GetPublicIpv6Pool/GetIPv6ElasticIPPool)")
+		}
+		var err error
+		ipv6s, err = s.netService.GetOrAllocateIPv6Addresses(s.scope.VPC().GetIPv6ElasticIPPool(), len(input.Subnets), getElasticIPRoleName())
+		if err != nil {
+			return fmt.Errorf("failed to allocate IPv6 address from pool %q to role %s: %w", aws.StringValue(s.scope.VPC().GetPublicIpv6Pool()), getElasticIPRoleName(), err)
+		}
+		if len(ipv6s) != len(input.Subnets) {
+			return fmt.Errorf("number of allocated IPv6 addresses (%d) from pool %q must match with the subnet count (%d)", len(ipv6s), aws.StringValue(s.scope.VPC().GetPublicIpv6Pool()), len(input.Subnets))
+		}
+	}
+
 	eips, err := s.netService.GetOrAllocateAddresses(s.scope.VPC().GetElasticIPPool(), len(input.Subnets), getElasticIPRoleName())
 	if err != nil {
-		return fmt.Errorf("failed to allocate address from Public IPv4 Pool %q to role %s: %w", *s.scope.VPC().GetPublicIpv4Pool(), getElasticIPRoleName(), err)
+		return fmt.Errorf("failed to allocate address from Public IPv4 Pool %q to role %s: %w", aws.StringValue(s.scope.VPC().GetPublicIpv4Pool()), getElasticIPRoleName(), err)
 	}
 	if len(eips) != len(input.Subnets) {
-		return fmt.Errorf("number of allocated EIP addresses (%d) from pool %q must match with the subnet count (%d)", len(eips), *s.scope.VPC().GetPublicIpv4Pool(), len(input.Subnets))
+		return fmt.Errorf("number of allocated EIP addresses (%d) from pool %q must match with the subnet count (%d)", len(eips), aws.StringValue(s.scope.VPC().GetPublicIpv4Pool()), len(input.Subnets))
 	}
 	for cnt, sb := range input.Subnets {
-		input.SubnetMappings = append(input.SubnetMappings, &elbv2.SubnetMapping{
+		mapping := &elbv2.SubnetMapping{
 			SubnetId:     aws.String(*sb),
 			AllocationId: aws.String(eips[cnt]),
+		}
+		if dualStack {
+			mapping.IPv6Address = aws.String(ipv6s[cnt])
+		}
+		input.SubnetMappings = append(input.SubnetMappings, mapping)
+	}
+	// Subnets and SubnetMappings are mutual exclusive. Cleaning Subnets when BYO IP is defined,
+	// and SubnetMappings are mounted.
+	input.Subnets = []*string{}
+
+	return nil
+}
+
+// isInternal reports whether input requests an internal-scheme load balancer.
+func isInternal(input *elbv2.CreateLoadBalancerInput) bool {
+	return input.Scheme != nil && *input.Scheme == elbv2.LoadBalancerSchemeEnumInternal
+}
+
+// allocatePrivateIpv4Addresses populates SubnetMappings with stable,
+// pre-approved private IPs drawn from VPC().GetPrivateIPv4Pool() for internal
+// NLBs, so that multiple clusters can agree on stable control-plane LB
+// addresses ahead of time instead of letting AWS pick one per reconcile.
+func (s *Service) allocatePrivateIpv4Addresses(input *elbv2.CreateLoadBalancerInput) error {
+	if input.Type == nil || *input.Type != string(elbv2.LoadBalancerTypeEnumNetwork) {
+		return fmt.Errorf("PrivateIPv4Address is supported only when the Load Balancer type is %q", elbv2.LoadBalancerTypeEnumNetwork)
+	}
+	if len(input.SubnetMappings) > 0 {
+		return fmt.Errorf("PrivateIPv4Address is mutually exclusive with SubnetMappings")
+	}
+
+	pool := s.scope.VPC().GetPrivateIPv4Pool()
+	if len(pool) == 0 {
+		return nil
+	}
+
+	addresses, err := s.netService.ReservePrivateIPv4Addresses(pool, input.Subnets, getElasticIPRoleName())
+	if err != nil {
+		return fmt.Errorf("failed to reserve private IPv4 addresses for internal Network Load Balancer: %w", err)
+	}
+
+	for cnt, sb := range input.Subnets {
+		input.SubnetMappings = append(input.SubnetMappings, &elbv2.SubnetMapping{
+			SubnetId:           aws.String(*sb),
+			PrivateIPv4Address: aws.String(addresses[cnt]),
 		})
 	}
 	// Subnets and SubnetMappings are mutual exclusive. Cleaning Subnets when BYO IP is defined,
@@ -50,8 +115,8 @@ func (s *Service) getOrAllocateAddresses(input *elbv2.CreateLoadBalancerInput) e
 // allocatePublicIpv4AddressFromByoIPPool claims for Elastic IPs from an user-defined public IPv4 pool,
 // allocating it to the NetworkMapping structure from an Network Load Balancer.
 func (s *Service) allocatePublicIpv4AddressFromByoIPPool(input *elbv2.CreateLoadBalancerInput) error {
-	// Custom Public IPv4 Pool isn't set.
-	if s.scope.VPC().GetPublicIpv4Pool() == nil {
+	// Neither a custom Public IPv4 Pool nor a dualstack BYO IPv6 pool is set.
+	if s.scope.VPC().GetPublicIpv4Pool() == nil && !(isDualStack(input) && s.scope.VPC().GetIPv6ElasticIPPool() != nil) {
 		return nil
 	}
 
@@ -66,8 +131,15 @@ func (s *Service) allocatePublicIpv4AddressFromByoIPPool(input *elbv2.CreateLoad
 // If there is no pre-allocated EIP, and the Public IPv4 Pool is not defined, it will
 // use the default configuration from the AWS pool.
 func (s *Service) allocatePublicIpv4Address(input *elbv2.CreateLoadBalancerInput) error {
-	// Custom Public IPv4 Pool is defined.
-	if s.scope.VPC().GetPublicIpv4Pool() != nil {
+	// Internal NLBs don't have public IPs to allocate; they get their stable
+	// addresses, if any, from the private IPv4 pool instead.
+	if isInternal(input) {
+		return s.allocatePrivateIpv4Addresses(input)
+	}
+
+	// Custom Public IPv4 Pool is defined, or a dualstack NLB needs its BYO IPv6
+	// address carried alongside a v4 AllocationId in the same SubnetMapping.
+	if s.scope.VPC().GetPublicIpv4Pool() != nil || (isDualStack(input) && s.scope.VPC().GetIPv6ElasticIPPool() != nil) {
 		return s.allocatePublicIpv4AddressFromByoIPPool(input)
 	}
 
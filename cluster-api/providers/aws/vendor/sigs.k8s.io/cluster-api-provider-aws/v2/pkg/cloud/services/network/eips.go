@@ -19,6 +19,9 @@ package network
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"math/big"
+	"net"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -32,6 +35,106 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
+// ipv6RolePartitionStride bounds how many addresses GetOrAllocateIPv6Addresses
+// reserves per role within each pool CIDR block, so two roles drawing from
+// the same BYO IPv6 pool (e.g. the control-plane and an ingress NLB) derive
+// non-overlapping offsets instead of both starting at offset 1 and racing
+// onto the same addresses.
+const ipv6RolePartitionStride = 4096
+
+// GetOrAllocateIPv6Addresses returns num IPv6 addresses carved out of pool
+// for a dualstack NLB's SubnetMapping.IPv6Address. Unlike IPv4 Elastic IPs,
+// BYOIP IPv6 addresses for NLBs are not allocated through AllocateAddress:
+// AWS requires only that the address fall within a CIDR block already
+// reserved from the pool, so addresses are derived deterministically from
+// the pool's reserved CIDR blocks rather than tracked as discrete resources.
+// Each role is given its own offset partition within a block via
+// ipv6RolePartitionOffset, and every derived address is bounds-checked
+// against the block it was derived from before being returned.
+func (s *Service) GetOrAllocateIPv6Addresses(pool *string, num int, role string) ([]string, error) {
+	if pool == nil {
+		return nil, errors.New("no IPv6 pool configured for dualstack Network Load Balancer")
+	}
+
+	out, err := s.EC2Client.DescribeIpv6PoolsWithContext(context.TODO(), &ec2.DescribeIpv6PoolsInput{
+		PoolIds: []*string{pool},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe IPv6 pool %q", *pool)
+	}
+	if len(out.Ipv6Pools) != 1 {
+		return nil, errors.Errorf("unexpected number of IPv6 pools. Want 1, got %d", len(out.Ipv6Pools))
+	}
+
+	cidrBlocks := out.Ipv6Pools[0].PoolCidrBlocks
+	if len(cidrBlocks) == 0 {
+		return nil, errors.Errorf("IPv6 pool %q has no reserved CIDR blocks", *pool)
+	}
+
+	rolePartition := ipv6RolePartitionOffset(role)
+
+	ips := make([]string, 0, num)
+	for i := 0; i < num; i++ {
+		cidrBlock := cidrBlocks[i%len(cidrBlocks)]
+		cidr := aws.StringValue(cidrBlock.Cidr)
+		offset := rolePartition + int64(i/len(cidrBlocks)) + 1
+
+		ip, err := ipv6AddressAtOffset(cidr, offset)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive IPv6 address %d from CIDR block %q for role %q", offset, cidr, role)
+		}
+		if err := validateIPv6InCIDR(ip, cidr); err != nil {
+			return nil, errors.Wrapf(err, "role %q exhausted its reserved offset range in CIDR block %q", role, cidr)
+		}
+		ips = append(ips, ip)
+	}
+
+	return ips, nil
+}
+
+// ipv6RolePartitionOffset derives a stable, role-specific starting offset
+// within a pool CIDR block, so distinct roles requesting addresses from the
+// same pool land in disjoint offset ranges instead of colliding.
+func ipv6RolePartitionOffset(role string) int64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(role))
+	return int64(h.Sum32()%1024) * ipv6RolePartitionStride
+}
+
+// validateIPv6InCIDR returns an error unless address falls within cidr, so a
+// derived offset that has run past the end of its reserved block is caught
+// instead of silently returned.
+func validateIPv6InCIDR(address, cidr string) error {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid CIDR block %q", cidr)
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil || !ipnet.Contains(ip) {
+		return errors.Errorf("derived IPv6 address %q is outside reserved CIDR block %q", address, cidr)
+	}
+
+	return nil
+}
+
+// ipv6AddressAtOffset returns the address at offset from the start of cidr.
+func ipv6AddressAtOffset(cidr string, offset int64) (string, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid CIDR block %q", cidr)
+	}
+
+	base := new(big.Int).SetBytes(ipnet.IP.To16())
+	addr := new(big.Int).Add(base, big.NewInt(offset))
+
+	addrBytes := addr.Bytes()
+	padded := make([]byte, 16)
+	copy(padded[16-len(addrBytes):], addrBytes)
+
+	return net.IP(padded).String(), nil
+}
+
 func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, err error) {
 	out, err := s.describeAddresses(role)
 	if err != nil {
@@ -58,13 +161,7 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 }
 
 func (s *Service) allocateAddress(role string) (string, error) {
-	tagSpecifications := tags.BuildParamsToTagSpecification(ec2.ResourceTypeElasticIp, s.getEIPTagParams(role))
-	allocInput := &ec2.AllocateAddressInput{
-		Domain: aws.String("vpc"),
-		TagSpecifications: []*ec2.TagSpecification{
-			tagSpecifications,
-		},
-	}
+	fellBackToAmazonPool := false
 
 	if s.scope.VPC().PublicIpv4Pool != nil {
 		ok, err := s.publicIpv4PoolHasFreeIPs(1)
@@ -72,10 +169,38 @@ func (s *Service) allocateAddress(role string) (string, error) {
 			record.Warnf(s.scope.InfraCluster(), "FailedAllocateEIP", "Failed to allocate Elastic IP for %q in Public IPv4 Pool %s", role, s.scope.VPC().PublicIpv4Pool)
 			return "", errors.New("failed to allocate Elastic IP from PublicIpv4 Pool")
 		}
-		if !ok && s.scope.VPC().PublicIpv4PoolFallBackOrder != nil && s.scope.VPC().PublicIpv4PoolFallBackOrder.Equal(infrav1.PublicIpv4PoolFallbackOrderNone) {
+
+		switch {
+		case ok:
+			// Pool has capacity, allocate from it below.
+		case s.scope.VPC().PublicIpv4PoolFallBackOrder != nil && s.scope.VPC().PublicIpv4PoolFallBackOrder.Equal(infrav1.PublicIpv4PoolFallbackOrderAmazonPool):
+			remaining, countErr := s.publicIpv4PoolFreeAddressCount()
+			if countErr != nil {
+				remaining = 0
+			}
+			record.Warnf(s.scope.InfraCluster(), "FellBackToAmazonPool", "Public IPv4 Pool %q exhausted for role %q (%d addresses remaining), falling back to an Amazon-owned address", aws.StringValue(s.scope.VPC().PublicIpv4Pool), role, remaining)
+			fellBackToAmazonPool = true
+		default:
 			record.Warnf(s.scope.InfraCluster(), "FailedAllocateEIPFromBYOIP", "Failed to allocate Elastic IP for %q in Public IPv4 Pool %s and fallback isnt enabled//", role, s.scope.VPC().PublicIpv4Pool)
 			return "", fmt.Errorf("failed to allocate Elastic IP from PublicIpv4 Pool and use fallback with strategy %s", *s.scope.VPC().PublicIpv4PoolFallBackOrder)
 		}
+	}
+
+	tagSpecifications := tags.BuildParamsToTagSpecification(ec2.ResourceTypeElasticIp, s.getEIPTagParams(role))
+	if fellBackToAmazonPool {
+		tagSpecifications.Tags = append(tagSpecifications.Tags, &ec2.Tag{
+			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/public-ipv4-pool-fallback"),
+			Value: aws.String("amazon-pool"),
+		})
+	}
+
+	allocInput := &ec2.AllocateAddressInput{
+		Domain: aws.String("vpc"),
+		TagSpecifications: []*ec2.TagSpecification{
+			tagSpecifications,
+		},
+	}
+	if s.scope.VPC().PublicIpv4Pool != nil && !fellBackToAmazonPool {
 		allocInput.PublicIpv4Pool = s.scope.VPC().PublicIpv4Pool
 	}
 
@@ -194,20 +319,242 @@ func (s *Service) GetOrAllocateAddresses(num int, role string) (eips []string, e
 	return s.getOrAllocateAddresses(num, role)
 }
 
+// GetAddresses returns the Elastic IPs tagged with the given role for this cluster.
+func (s *Service) GetAddresses(role string) (*ec2.DescribeAddressesOutput, error) {
+	return s.describeAddresses(role)
+}
+
+// ReleaseAddressByRole releases every Elastic IP tagged with the given role.
+// Unlike releaseAddresses, it keeps attempting the remaining addresses when
+// one fails to disassociate or release, so a single stuck address cannot
+// strand the rest; the first error encountered is returned once all
+// addresses have been attempted.
+func (s *Service) ReleaseAddressByRole(role string) error {
+	out, err := s.describeAddresses(role)
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe elastic IPs for role %q", role)
+	}
+	if out == nil {
+		return nil
+	}
+
+	var firstErr error
+	for i := range out.Addresses {
+		ip := out.Addresses[i]
+		if ip.AssociationId != nil {
+			if err := s.disassociateAddress(ip); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+		}
+
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			_, err := s.EC2Client.ReleaseAddressWithContext(context.TODO(), &ec2.ReleaseAddressInput{AllocationId: ip.AllocationId})
+			return err == nil, err
+		}, awserrors.AuthFailure, awserrors.InUseIPAddress); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedReleaseEIP", "Failed to release Elastic IP %q: %v", *ip.AllocationId, err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "failed to release ElasticIP %q", *ip.AllocationId)
+			}
+			continue
+		}
+
+		s.scope.Info("released ElasticIP", "eip", *ip.PublicIp, "allocation-id", *ip.AllocationId, "role", role)
+	}
+
+	return firstErr
+}
+
 func (s *Service) publicIpv4PoolHasFreeIPs(want int64) (bool, error) {
+	remaining, err := s.publicIpv4PoolFreeAddressCount()
+	if err != nil {
+		return false, err
+	}
+
+	if remaining < want {
+		return false, nil
+	}
+	s.scope.Debug("public IPv4 pool has %d IPs available", "eip", remaining)
+	return true, nil
+}
+
+// publicIpv4PoolFreeAddressCount returns the number of addresses still
+// available for allocation in the configured Public IPv4 Pool.
+func (s *Service) publicIpv4PoolFreeAddressCount() (int64, error) {
 	pools, err := s.EC2Client.DescribePublicIpv4Pools(&ec2.DescribePublicIpv4PoolsInput{
 		PoolIds: []*string{s.scope.VPC().PublicIpv4Pool},
 	})
 	if err != nil {
-		return false, errors.Wrapf(err, "failed to describe elastic IPs %q", err)
+		return 0, errors.Wrapf(err, "failed to describe elastic IPs %q", err)
 	}
 	if len(pools.PublicIpv4Pools) == 0 || len(pools.PublicIpv4Pools) > 1 {
-		return false, errors.Wrapf(err, "unexpected number of Public IPv4 Pools. Want 1, got %d", len(pools.PublicIpv4Pools))
+		return 0, errors.Wrapf(err, "unexpected number of Public IPv4 Pools. Want 1, got %d", len(pools.PublicIpv4Pools))
 	}
 
-	if aws.Int64Value(pools.PublicIpv4Pools[0].TotalAvailableAddressCount) < want {
-		return false, nil
+	return aws.Int64Value(pools.PublicIpv4Pools[0].TotalAvailableAddressCount), nil
+}
+
+// ReservePrivateIPv4Addresses validates and reserves num pre-approved private
+// IPv4 addresses, one per entry in subnetIDs, for an internal Network Load
+// Balancer's SubnetMappings. Each candidate address is drawn from pool in
+// order, must fall inside the CIDR of the subnet it is mapped to, and must
+// not already be in use by another ENI. The address is then reserved by
+// tagging a placeholder ENI in the subnet, so that a concurrent reconcile
+// in another cluster sharing the same pre-approved pool cannot race onto
+// the same address.
+//
+// A candidate already reserved by our own placeholder ENI from a prior
+// reconcile is treated as already-reserved rather than in-use, mirroring
+// how getOrAllocateAddresses reuses an existing tagged EIP instead of
+// allocating a new one -- otherwise every reconcile after the first would
+// fail with "already in use" against its own placeholder.
+func (s *Service) ReservePrivateIPv4Addresses(pool []string, subnetIDs []*string, role string) ([]string, error) {
+	if len(pool) < len(subnetIDs) {
+		return nil, errors.Errorf("private IPv4 pool has %d addresses, need %d for %d subnets", len(pool), len(subnetIDs), len(subnetIDs))
+	}
+
+	reserved := make([]string, 0, len(subnetIDs))
+	for i, subnetID := range subnetIDs {
+		candidate := pool[i]
+
+		cidrBlock, err := s.subnetCIDRBlock(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		if err := validatePrivateIPInCIDR(candidate, cidrBlock); err != nil {
+			return nil, err
+		}
+
+		reservedByUs, err := s.privateIPv4AddressReservedByUs(candidate, role)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check for an existing placeholder reservation of private IPv4 address %q", candidate)
+		}
+		if reservedByUs {
+			reserved = append(reserved, candidate)
+			continue
+		}
+
+		inUse, err := s.privateIPv4AddressInUse(candidate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check if private IPv4 address %q is already in use", candidate)
+		}
+		if inUse {
+			return nil, errors.Errorf("private IPv4 address %q is already in use by another network interface", candidate)
+		}
+
+		if err := s.reservePrivateIPv4Placeholder(aws.StringValue(subnetID), candidate, role); err != nil {
+			return nil, errors.Wrapf(err, "failed to reserve private IPv4 address %q", candidate)
+		}
+
+		reserved = append(reserved, candidate)
+	}
+
+	return reserved, nil
+}
+
+// privateIPv4AddressReservedByUs reports whether address is already claimed
+// by one of our own placeholder ENIs for role, i.e. one created by a prior
+// call to reservePrivateIPv4Placeholder, identified the same way
+// describeAddresses identifies a cluster's own Elastic IPs: by the cluster
+// and role tags reservePrivateIPv4Placeholder stamps onto it.
+func (s *Service) privateIPv4AddressReservedByUs(address, role string) (bool, error) {
+	out, err := s.EC2Client.DescribeNetworkInterfacesWithContext(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.ProviderRole(role),
+			{
+				Name:   aws.String("addresses.private-ip-address"),
+				Values: []*string{aws.String(address)},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(out.NetworkInterfaces) > 0, nil
+}
+
+// subnetCIDRBlock returns the IPv4 CIDR block of subnetID.
+func (s *Service) subnetCIDRBlock(subnetID *string) (string, error) {
+	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{subnetID},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe subnet %q", aws.StringValue(subnetID))
+	}
+	if len(out.Subnets) != 1 {
+		return "", errors.Errorf("unexpected number of subnets for %q. Want 1, got %d", aws.StringValue(subnetID), len(out.Subnets))
+	}
+
+	return aws.StringValue(out.Subnets[0].CidrBlock), nil
+}
+
+// validatePrivateIPInCIDR returns an error unless address falls within cidr.
+func validatePrivateIPInCIDR(address, cidr string) error {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid CIDR block %q", cidr)
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil || !ipnet.Contains(ip) {
+		return errors.Errorf("private IPv4 address %q is not within subnet CIDR %q", address, cidr)
+	}
+
+	return nil
+}
+
+// privateIPv4AddressInUse reports whether address is already assigned to an
+// existing network interface.
+func (s *Service) privateIPv4AddressInUse(address string) (bool, error) {
+	out, err := s.EC2Client.DescribeNetworkInterfacesWithContext(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("addresses.private-ip-address"),
+				Values: []*string{aws.String(address)},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(out.NetworkInterfaces) > 0, nil
+}
+
+// reservePrivateIPv4Placeholder claims address in subnetID by creating a
+// placeholder network interface tagged for role, preventing a concurrent
+// reconcile from allocating the same pre-approved address.
+func (s *Service) reservePrivateIPv4Placeholder(subnetID, address, role string) error {
+	tagSpecifications := tags.BuildParamsToTagSpecification(ec2.ResourceTypeNetworkInterface, s.getEIPTagParams(role))
+
+	_, err := s.EC2Client.CreateNetworkInterfaceWithContext(context.TODO(), &ec2.CreateNetworkInterfaceInput{
+		SubnetId:          aws.String(subnetID),
+		PrivateIpAddress:  aws.String(address),
+		Description:       aws.String(fmt.Sprintf("placeholder reservation for %s", role)),
+		TagSpecifications: []*ec2.TagSpecification{tagSpecifications},
+	})
+
+	return err
+}
+
+// ValidatePublicIpv4PoolFallbackOrder rejects unknown PublicIpv4PoolFallbackOrder
+// strategies. It is meant to be called from the AWSCluster/AWSManagedControlPlane
+// validating webhook so that a typo in the field surfaces at admission time
+// rather than at EIP-allocation time.
+func ValidatePublicIpv4PoolFallbackOrder(order *infrav1.PublicIpv4PoolFallbackOrder) error {
+	if order == nil {
+		return nil
+	}
+
+	switch {
+	case order.Equal(infrav1.PublicIpv4PoolFallbackOrderNone),
+		order.Equal(infrav1.PublicIpv4PoolFallbackOrderAmazonPool):
+		return nil
+	default:
+		return fmt.Errorf("unknown publicIpv4PoolFallbackOrder strategy %q", *order)
 	}
-	s.scope.Debug("public IPv4 pool has %d IPs available", "eip", aws.Int64Value(pools.PublicIpv4Pools[0].TotalAvailableAddressCount))
-	return true, nil
 }
@@ -0,0 +1,95 @@
+package rosa
+
+import (
+	"fmt"
+	"regexp"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// auditLogRoleARNPattern matches an IAM role ARN, e.g.
+// arn:aws:iam::123456789012:role/audit-log-forwarding.
+var auditLogRoleARNPattern = regexp.MustCompile(`^arn:aws:iam::\d{12}:role/[\w+=,.@-]+$`)
+
+// ValidateAuditLogRoleARN checks that roleARN is a well-formed IAM role ARN
+// suitable for ROSA audit log forwarding to CloudWatch.
+func ValidateAuditLogRoleARN(roleARN string) error {
+	if !auditLogRoleARNPattern.MatchString(roleARN) {
+		return fmt.Errorf("auditLogRoleARN %q is not a valid IAM role ARN", roleARN)
+	}
+	return nil
+}
+
+// SetAuditLogForwarding enables forwarding of control-plane audit logs to
+// CloudWatch by setting the cluster's AuditLog.RoleArn to roleARN. The role
+// must already trust the ROSA installer/operator per the STS trust policy;
+// this call only wires the cluster to use it.
+//
+// TODO(mtulio/installer#chunk1-1): call this from the ROSA control plane
+// reconciler, diffing roleARN against GetAuditLogRoleARN and surfacing the
+// result as an AuditLogForwardingReady condition, once this vendor
+// checkout carries a RosaControlPlaneSpec to read the desired ARN from
+// (see pkg/rosa/idp_reconcile.go for the equivalent pattern used by the
+// identity-provider reconciler) -- neither the field nor the condition
+// exist in this tree yet, so nothing calls SetAuditLogForwarding or
+// GetAuditLogRoleARN today.
+func (c *RosaClient) SetAuditLogForwarding(clusterID, roleARN string) error {
+	if err := ValidateAuditLogRoleARN(roleARN); err != nil {
+		return err
+	}
+
+	auditLog, err := cmv1.NewAuditLog().RoleArn(roleARN).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build audit log config for cluster '%s': %w", clusterID, err)
+	}
+
+	clusterUpdate, err := cmv1.NewCluster().AuditLog(auditLog).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build cluster update for audit log forwarding: %w", err)
+	}
+
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Update().Body(clusterUpdate).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+
+	return nil
+}
+
+// DisableAuditLogForwarding clears the cluster's AuditLog.RoleArn, stopping
+// the forwarding of audit logs to CloudWatch.
+func (c *RosaClient) DisableAuditLogForwarding(clusterID string) error {
+	auditLog, err := cmv1.NewAuditLog().RoleArn("").Build()
+	if err != nil {
+		return fmt.Errorf("failed to build audit log config for cluster '%s': %w", clusterID, err)
+	}
+
+	clusterUpdate, err := cmv1.NewCluster().AuditLog(auditLog).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build cluster update for audit log forwarding: %w", err)
+	}
+
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Update().Body(clusterUpdate).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+
+	return nil
+}
+
+// GetAuditLogRoleARN returns the IAM role ARN currently configured for audit
+// log forwarding on the cluster, or the empty string if forwarding is
+// disabled.
+func (c *RosaClient) GetAuditLogRoleARN(clusterID string) (string, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().Send()
+	if err != nil {
+		return "", handleErr(response.Error(), err)
+	}
+
+	cluster := response.Body()
+	if cluster.AuditLog() == nil {
+		return "", nil
+	}
+
+	return cluster.AuditLog().RoleArn(), nil
+}
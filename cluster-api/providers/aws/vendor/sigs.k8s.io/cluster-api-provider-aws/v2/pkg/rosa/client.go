@@ -5,13 +5,8 @@ import (
 	"fmt"
 	"os"
 
-<<<<<<< HEAD
-	ocmcfg "github.com/openshift/rosa/pkg/config"
-	"github.com/openshift/rosa/pkg/ocm"
-	"github.com/sirupsen/logrus"
-=======
 	sdk "github.com/openshift-online/ocm-sdk-go"
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
+	"github.com/openshift-online/ocm-sdk-go/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -22,20 +17,9 @@ const (
 	ocmAPIURLKey = "ocmApiUrl"
 )
 
-<<<<<<< HEAD
-func NewOCMClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*ocm.Client, error) {
-	token, url, err := ocmCredentials(ctx, rosaScope)
-	if err != nil {
-		return nil, err
-	}
-	return ocm.NewClient().Logger(logrus.New()).Config(&ocmcfg.Config{
-		AccessToken: token,
-		URL:         url,
-	}).Build()
-}
-
-func ocmCredentials(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (string, string, error) {
-=======
+// RosaClient wraps an OCM SDK connection scoped to a single ROSA control
+// plane, so that callers do not have to thread a connection and cluster ID
+// through every OCM operation separately.
 type RosaClient struct {
 	ocm       *sdk.Connection
 	rosaScope *scope.ROSAControlPlaneScope
@@ -49,19 +33,17 @@ func NewRosaClientWithConnection(connection *sdk.Connection, rosaScope *scope.RO
 	}
 }
 
+// NewRosaClient builds an OCM connection from the ROSAControlPlaneScope's
+// credentials secret (or OCM_TOKEN/OCM_API_URL as a fallback) and returns a
+// RosaClient ready to issue OCM API calls.
 func NewRosaClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*RosaClient, error) {
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 	var token string
 	var ocmAPIUrl string
 
 	secret := rosaScope.CredentialsSecret()
 	if secret != nil {
 		if err := rosaScope.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
-<<<<<<< HEAD
-			return "", "", fmt.Errorf("failed to get credentials secret: %w", err)
-=======
 			return nil, fmt.Errorf("failed to get credentials secret: %w", err)
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 		}
 
 		token = string(secret.Data[ocmTokenKey])
@@ -75,11 +57,6 @@ func NewRosaClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope)
 	}
 
 	if token == "" {
-<<<<<<< HEAD
-		return "", "", fmt.Errorf("token is not provided, be sure to set OCM_TOKEN env variable or reference a credentials secret with key %s", ocmTokenKey)
-	}
-	return token, ocmAPIUrl, nil
-=======
 		return nil, fmt.Errorf("token is not provided, be sure to set OCM_TOKEN env variable or reference a credentials secret with key %s", ocmTokenKey)
 	}
 
@@ -106,15 +83,27 @@ func NewRosaClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope)
 	}, nil
 }
 
+// Close releases the underlying OCM connection.
 func (c *RosaClient) Close() error {
 	return c.ocm.Close()
 }
 
+// GetConnectionURL returns the OCM API URL the client is connected to.
 func (c *RosaClient) GetConnectionURL() string {
 	return c.ocm.URL()
 }
 
+// GetConnectionTokens returns the access and refresh tokens for the current connection.
 func (c *RosaClient) GetConnectionTokens() (string, string, error) {
 	return c.ocm.Tokens()
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
+}
+
+// handleErr unwraps an OCM API error response into a Go error, falling back
+// to the transport-level error when the response carries no error body.
+func handleErr(res *errors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return fmt.Errorf("%s", msg)
 }
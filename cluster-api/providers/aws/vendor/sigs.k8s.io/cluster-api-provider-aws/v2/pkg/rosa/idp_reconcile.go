@@ -0,0 +1,270 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SecretKeyRef names a key within a Kubernetes Secret, used to resolve
+// client secrets and other sensitive identity provider fields without
+// storing them inline on the control plane spec.
+type SecretKeyRef struct {
+	Name      string
+	Namespace string
+	Key       string
+}
+
+// GitHubIdentityProviderSpec configures a GitHub OAuth identity provider.
+type GitHubIdentityProviderSpec struct {
+	ClientID        string
+	ClientSecretRef SecretKeyRef
+	Organizations   []string
+	Teams           []string
+	Hostname        string
+}
+
+// GoogleIdentityProviderSpec configures a Google OAuth identity provider.
+type GoogleIdentityProviderSpec struct {
+	ClientID        string
+	ClientSecretRef SecretKeyRef
+	HostedDomain    string
+}
+
+// GitLabIdentityProviderSpec configures a GitLab OAuth identity provider.
+type GitLabIdentityProviderSpec struct {
+	ClientID        string
+	ClientSecretRef SecretKeyRef
+	URL             string
+}
+
+// OpenIDIdentityProviderSpec configures a generic OpenID Connect identity provider.
+type OpenIDIdentityProviderSpec struct {
+	ClientID        string
+	ClientSecretRef SecretKeyRef
+	Issuer          string
+	Claims          []string
+}
+
+// LDAPIdentityProviderSpec configures an LDAP identity provider.
+type LDAPIdentityProviderSpec struct {
+	URL             string
+	BindDN          string
+	BindPasswordRef SecretKeyRef
+	Insecure        bool
+	Attributes      []string
+}
+
+// IdentityProviderSpec is one identity provider declared on the control
+// plane. Exactly one of the type-specific fields should be set; Type selects
+// which one is used to build the IDP sent to OCM.
+type IdentityProviderSpec struct {
+	Name     string
+	Type     cmv1.IdentityProviderType
+	HTPasswd *HTPasswdIdentityProviderSpec
+	GitHub   *GitHubIdentityProviderSpec
+	Google   *GoogleIdentityProviderSpec
+	GitLab   *GitLabIdentityProviderSpec
+	OpenID   *OpenIDIdentityProviderSpec
+	LDAP     *LDAPIdentityProviderSpec
+}
+
+// HTPasswdIdentityProviderSpec configures a static HTPasswd identity provider.
+type HTPasswdIdentityProviderSpec struct {
+	Username string
+	Password string
+}
+
+// UpdateIdentityProvider updates the mutable fields of an existing identity provider.
+func (c *RosaClient) UpdateIdentityProvider(clusterID string, idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idp.ID()).
+		Update().Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// DeleteIdentityProvider removes an identity provider from the cluster.
+func (c *RosaClient) DeleteIdentityProvider(clusterID, idpID string) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// ReconcileIdentityProviders diffs the identity providers declared in specs
+// against the cluster's live identity providers and creates, updates or
+// deletes them to converge. The cluster-admin HTPasswd IDP managed by
+// CreateAdminUserIfNotExist is never touched by this reconciler, even if it
+// is not present in specs.
+func (c *RosaClient) ReconcileIdentityProviders(ctx context.Context, clusterID string, specs []IdentityProviderSpec) error {
+	live, err := c.ListIdentityProviders(clusterID)
+	if err != nil {
+		return fmt.Errorf("failed to list identity providers for cluster '%s': %w", clusterID, err)
+	}
+
+	liveByName := make(map[string]*cmv1.IdentityProvider, len(live))
+	for _, idp := range live {
+		liveByName[idp.Name()] = idp
+	}
+
+	wantByName := make(map[string]struct{}, len(specs))
+	for _, spec := range specs {
+		wantByName[spec.Name] = struct{}{}
+
+		idp, err := c.buildIdentityProvider(ctx, spec)
+		if err != nil {
+			return fmt.Errorf("failed to build identity provider '%s': %w", spec.Name, err)
+		}
+
+		if existing, ok := liveByName[spec.Name]; ok {
+			if _, err := c.UpdateIdentityProvider(clusterID, idp); err != nil {
+				return fmt.Errorf("failed to update identity provider '%s': %w", existing.Name(), err)
+			}
+			continue
+		}
+
+		if _, err := c.CreateIdentityProvider(clusterID, idp); err != nil {
+			return fmt.Errorf("failed to create identity provider '%s': %w", spec.Name, err)
+		}
+	}
+
+	for name, idp := range liveByName {
+		if name == clusterAdminIDPname {
+			continue
+		}
+		if _, ok := wantByName[name]; ok {
+			continue
+		}
+		if err := c.DeleteIdentityProvider(clusterID, idp.ID()); err != nil {
+			return fmt.Errorf("failed to delete identity provider '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// buildIdentityProvider resolves referenced secrets and builds the cmv1
+// identity provider matching spec's type.
+func (c *RosaClient) buildIdentityProvider(ctx context.Context, spec IdentityProviderSpec) (*cmv1.IdentityProvider, error) {
+	builder := cmv1.NewIdentityProvider().Type(spec.Type).Name(spec.Name)
+
+	switch spec.Type {
+	case cmv1.IdentityProviderTypeHtpasswd:
+		if spec.HTPasswd == nil {
+			return nil, fmt.Errorf("identity provider '%s' is of type HTPasswd but has no HTPasswd config", spec.Name)
+		}
+		htpasswdIDP := cmv1.NewHTPasswdIdentityProvider().Users(cmv1.NewHTPasswdUserList().Items(
+			cmv1.NewHTPasswdUser().Username(spec.HTPasswd.Username).Password(spec.HTPasswd.Password),
+		))
+		builder = builder.Htpasswd(htpasswdIDP)
+
+	case cmv1.IdentityProviderTypeGithub:
+		if spec.GitHub == nil {
+			return nil, fmt.Errorf("identity provider '%s' is of type GitHub but has no GitHub config", spec.Name)
+		}
+		secret, err := c.resolveSecret(ctx, spec.GitHub.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		githubIDP := cmv1.NewGithubIdentityProvider().
+			ClientID(spec.GitHub.ClientID).
+			ClientSecret(secret).
+			Hostname(spec.GitHub.Hostname).
+			Organizations(spec.GitHub.Organizations...).
+			Teams(spec.GitHub.Teams...)
+		builder = builder.Github(githubIDP)
+
+	case cmv1.IdentityProviderTypeGoogle:
+		if spec.Google == nil {
+			return nil, fmt.Errorf("identity provider '%s' is of type Google but has no Google config", spec.Name)
+		}
+		secret, err := c.resolveSecret(ctx, spec.Google.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		googleIDP := cmv1.NewGoogleIdentityProvider().
+			ClientID(spec.Google.ClientID).
+			ClientSecret(secret).
+			HostedDomain(spec.Google.HostedDomain)
+		builder = builder.Google(googleIDP)
+
+	case cmv1.IdentityProviderTypeGitlab:
+		if spec.GitLab == nil {
+			return nil, fmt.Errorf("identity provider '%s' is of type GitLab but has no GitLab config", spec.Name)
+		}
+		secret, err := c.resolveSecret(ctx, spec.GitLab.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		gitlabIDP := cmv1.NewGitlabIdentityProvider().
+			ClientID(spec.GitLab.ClientID).
+			ClientSecret(secret).
+			URL(spec.GitLab.URL)
+		builder = builder.Gitlab(gitlabIDP)
+
+	case cmv1.IdentityProviderTypeOpenID:
+		if spec.OpenID == nil {
+			return nil, fmt.Errorf("identity provider '%s' is of type OpenID but has no OpenID config", spec.Name)
+		}
+		secret, err := c.resolveSecret(ctx, spec.OpenID.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		openIDIDP := cmv1.NewOpenIDIdentityProvider().
+			ClientID(spec.OpenID.ClientID).
+			ClientSecret(secret).
+			Issuer(spec.OpenID.Issuer).
+			Claims(cmv1.NewOpenIDClaims().Email(spec.OpenID.Claims...))
+		builder = builder.OpenID(openIDIDP)
+
+	case cmv1.IdentityProviderTypeLDAP:
+		if spec.LDAP == nil {
+			return nil, fmt.Errorf("identity provider '%s' is of type LDAP but has no LDAP config", spec.Name)
+		}
+		bindPassword, err := c.resolveSecret(ctx, spec.LDAP.BindPasswordRef)
+		if err != nil {
+			return nil, err
+		}
+		ldapIDP := cmv1.NewLDAPIdentityProvider().
+			URL(spec.LDAP.URL).
+			BindDN(spec.LDAP.BindDN).
+			BindPassword(bindPassword).
+			Insecure(spec.LDAP.Insecure).
+			Attributes(cmv1.NewLDAPAttributes().ID(spec.LDAP.Attributes...))
+		builder = builder.LDAP(ldapIDP)
+
+	default:
+		return nil, fmt.Errorf("identity provider '%s' has unsupported type %q", spec.Name, spec.Type)
+	}
+
+	return builder.Build()
+}
+
+// resolveSecret fetches a single key out of a referenced Kubernetes Secret.
+func (c *RosaClient) resolveSecret(ctx context.Context, ref SecretKeyRef) (string, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+	if err := c.rosaScope.Client.Get(ctx, key, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret '%s/%s': %w", ref.Namespace, ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret '%s/%s' has no key %q", ref.Namespace, ref.Name, ref.Key)
+	}
+
+	return string(value), nil
+}
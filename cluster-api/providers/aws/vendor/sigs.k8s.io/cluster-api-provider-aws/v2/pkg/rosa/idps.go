@@ -2,13 +2,6 @@ package rosa
 
 import (
 	"fmt"
-<<<<<<< HEAD
-
-	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
-	"github.com/openshift/rosa/pkg/ocm"
-)
-
-=======
 	"net/http"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -67,7 +60,6 @@ func (c *RosaClient) AddHTPasswdUser(username, password, clusterID, idpID string
 	return nil
 }
 
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 const (
 	clusterAdminUserGroup = "cluster-admins"
 	clusterAdminIDPname   = "cluster-admin"
@@ -75,13 +67,8 @@ const (
 
 // CreateAdminUserIfNotExist creates a new admin user withe username/password in the cluster if username doesn't already exist.
 // the user is granted admin privileges by being added to a special IDP called `cluster-admin` which will be created if it doesn't already exist.
-<<<<<<< HEAD
-func CreateAdminUserIfNotExist(client *ocm.Client, clusterID, username, password string) error {
-	existingClusterAdminIDP, userList, err := findExistingClusterAdminIDP(client, clusterID)
-=======
 func (c *RosaClient) CreateAdminUserIfNotExist(clusterID, username, password string) error {
 	existingClusterAdminIDP, userList, err := c.findExistingClusterAdminIDP(clusterID)
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 	if err != nil {
 		return fmt.Errorf("failed to find existing cluster admin IDP: %w", err)
 	}
@@ -93,11 +80,7 @@ func (c *RosaClient) CreateAdminUserIfNotExist(clusterID, username, password str
 	}
 
 	// Add admin user to the cluster-admins group:
-<<<<<<< HEAD
-	user, err := CreateUserIfNotExist(client, clusterID, clusterAdminUserGroup, username)
-=======
 	user, err := c.CreateUserIfNotExist(clusterID, clusterAdminUserGroup, username)
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 	if err != nil {
 		return fmt.Errorf("failed to add user '%s' to cluster '%s': %s",
 			username, clusterID, err)
@@ -105,11 +88,7 @@ func (c *RosaClient) CreateAdminUserIfNotExist(clusterID, username, password str
 
 	if existingClusterAdminIDP != nil {
 		// add htpasswd user to existing idp
-<<<<<<< HEAD
-		err := client.AddHTPasswdUser(username, password, clusterID, existingClusterAdminIDP.ID())
-=======
 		err := c.AddHTPasswdUser(username, password, clusterID, existingClusterAdminIDP.ID())
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 		if err != nil {
 			return fmt.Errorf("failed to add htpassawoed user cluster-admin to existing idp: %s", existingClusterAdminIDP.ID())
 		}
@@ -135,17 +114,10 @@ func (c *RosaClient) CreateAdminUserIfNotExist(clusterID, username, password str
 	}
 
 	// Add HTPasswd IDP to cluster
-<<<<<<< HEAD
-	_, err = client.CreateIdentityProvider(clusterID, clusterAdminIDP)
-	if err != nil {
-		// since we could not add the HTPasswd IDP to the cluster, roll back and remove the cluster admin
-		if err := client.DeleteUser(clusterID, clusterAdminUserGroup, user.ID()); err != nil {
-=======
 	_, err = c.CreateIdentityProvider(clusterID, clusterAdminIDP)
 	if err != nil {
 		// since we could not add the HTPasswd IDP to the cluster, roll back and remove the cluster admin
 		if err := c.DeleteUser(clusterID, clusterAdminUserGroup, user.ID()); err != nil {
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 			return fmt.Errorf("failed to revert the admin user for cluster '%s': %w",
 				clusterID, err)
 		}
@@ -155,29 +127,9 @@ func (c *RosaClient) CreateAdminUserIfNotExist(clusterID, username, password str
 	return nil
 }
 
-<<<<<<< HEAD
-// CreateUserIfNotExist creates a new user with `username` and adds it to the group if it doesn't already exist.
-func CreateUserIfNotExist(client *ocm.Client, clusterID string, group, username string) (*cmv1.User, error) {
-	user, err := client.GetUser(clusterID, group, username)
-	if user != nil || err != nil {
-		return user, err
-	}
-
-	userCfg, err := cmv1.NewUser().ID(username).Build()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user '%s' for cluster '%s': %w", username, clusterID, err)
-	}
-	return client.CreateUser(clusterID, group, userCfg)
-}
-
-func findExistingClusterAdminIDP(client *ocm.Client, clusterID string) (
-	htpasswdIDP *cmv1.IdentityProvider, userList *cmv1.HTPasswdUserList, reterr error) {
-	idps, err := client.GetIdentityProviders(clusterID)
-=======
 func (c *RosaClient) findExistingClusterAdminIDP(clusterID string) (
 	htpasswdIDP *cmv1.IdentityProvider, userList *cmv1.HTPasswdUserList, reterr error) {
 	idps, err := c.ListIdentityProviders(clusterID)
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 	if err != nil {
 		reterr = fmt.Errorf("failed to get identity providers for cluster '%s': %v", clusterID, err)
 		return
@@ -185,11 +137,7 @@ func (c *RosaClient) findExistingClusterAdminIDP(clusterID string) (
 
 	for _, idp := range idps {
 		if idp.Name() == clusterAdminIDPname {
-<<<<<<< HEAD
-			itemUserList, err := client.GetHTPasswdUserList(clusterID, idp.ID())
-=======
 			itemUserList, err := c.GetHTPasswdUserList(clusterID, idp.ID())
->>>>>>> 9cb2dd3334 (cluster-api/providers/aws: vendor)
 			if err != nil {
 				reterr = fmt.Errorf("failed to get user list of the HTPasswd IDP of '%s: %s': %v", idp.Name(), clusterID, err)
 				return
@@ -0,0 +1,212 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	oauthChallengingClientID = "openshift-challenging-client"
+
+	// adminKubeconfigOAuthRetryInterval and adminKubeconfigOAuthTimeout bound
+	// how long GetAdminKubeconfig waits for the router to come up, since the
+	// OAuth route is only servable once the ingress/router pods are ready.
+	adminKubeconfigOAuthRetryInterval = 10 * time.Second
+	adminKubeconfigOAuthTimeout       = 10 * time.Minute
+)
+
+// GetAdminKubeconfig resolves the cluster's API URL and OAuth server, signs
+// in with the HTPasswd admin created by CreateAdminUserIfNotExist, and
+// returns a ready-to-use kubeconfig authenticated as that user.
+func (c *RosaClient) GetAdminKubeconfig(ctx context.Context, clusterID, username, password string) (*clientcmdapi.Config, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	cluster := response.Body()
+
+	apiURL := cluster.API().URL()
+	oauthURL := fmt.Sprintf("https://oauth-openshift.apps.%s", cluster.DNS().BaseDomain())
+
+	token, err := c.requestOAuthTokenWithRetry(ctx, oauthURL, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain OAuth token for cluster '%s': %w", clusterID, err)
+	}
+
+	caData, err := c.getClusterCA(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain CA for cluster '%s': %w", clusterID, err)
+	}
+
+	return buildKubeconfig(clusterID, apiURL, caData, username, token), nil
+}
+
+// requestOAuthTokenWithRetry polls requestOAuthToken until the OAuth route
+// becomes available (the router is not ready immediately after cluster
+// install) or the timeout elapses.
+func (c *RosaClient) requestOAuthTokenWithRetry(ctx context.Context, oauthURL, username, password string) (string, error) {
+	deadline := time.Now().Add(adminKubeconfigOAuthTimeout)
+	var lastErr error
+	for {
+		token, err := requestOAuthToken(ctx, oauthURL, username, password)
+		if err == nil {
+			return token, nil
+		}
+		lastErr = err
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for OAuth server to become available: %w", lastErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(adminKubeconfigOAuthRetryInterval):
+		}
+	}
+}
+
+// requestOAuthToken performs the OAuth "implicit" password-grant flow used
+// by oc login against an OpenShift OAuth server: it authenticates with HTTP
+// Basic auth against the authorize endpoint of the challenging client and
+// recovers the access token from the fragment of the redirected Location.
+func requestOAuthToken(ctx context.Context, oauthURL, username, password string) (string, error) {
+	authorizeURL := fmt.Sprintf("%s/oauth/authorize?response_type=token&client_id=%s", oauthURL, oauthChallengingClientID)
+
+	httpClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, authorizeURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(username, password)
+	req.Header.Set("X-CSRF-Token", "1")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach OAuth server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return "", fmt.Errorf("unexpected OAuth response status %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse OAuth redirect location: %w", err)
+	}
+
+	fragment, err := url.ParseQuery(location.Fragment)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse OAuth redirect fragment: %w", err)
+	}
+
+	token := fragment.Get("access_token")
+	if token == "" {
+		return "", fmt.Errorf("OAuth redirect did not include an access_token")
+	}
+
+	return token, nil
+}
+
+// getClusterCA returns the cluster's serving CA bundle, recovered from the
+// OCM-issued admin kubeconfig for the cluster.
+func (c *RosaClient) getClusterCA(clusterID string) ([]byte, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Credentials().Get().Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+
+	kubeconfig := response.Body().Kubeconfig()
+	if kubeconfig == "" {
+		return nil, fmt.Errorf("cluster '%s' has no admin kubeconfig to recover a CA bundle from", clusterID)
+	}
+
+	cfg, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OCM-issued kubeconfig: %w", err)
+	}
+
+	for _, c := range cfg.Clusters {
+		if len(c.CertificateAuthorityData) > 0 {
+			return c.CertificateAuthorityData, nil
+		}
+	}
+
+	return nil, fmt.Errorf("OCM-issued kubeconfig for cluster '%s' has no embedded CA", clusterID)
+}
+
+// buildKubeconfig assembles a single-context kubeconfig authenticating as
+// username via bearer token against apiURL.
+func buildKubeconfig(clusterID, apiURL string, caData []byte, username, token string) *clientcmdapi.Config {
+	contextName := fmt.Sprintf("%s/%s", clusterID, username)
+
+	return &clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			clusterID: {
+				Server:                   apiURL,
+				CertificateAuthorityData: caData,
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			username: {
+				Token: token,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			contextName: {
+				Cluster:  clusterID,
+				AuthInfo: username,
+			},
+		},
+		CurrentContext: contextName,
+	}
+}
+
+// PersistAdminKubeconfig writes cfg into a Secret named
+// "<clusterName>-admin-kubeconfig" in namespace, creating it if absent or
+// updating it in place otherwise, so that downstream CAPI controllers can
+// bootstrap add-ons against the cluster.
+func (c *RosaClient) PersistAdminKubeconfig(ctx context.Context, namespace, clusterName string, cfg *clientcmdapi.Config) error {
+	data, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return fmt.Errorf("failed to serialize admin kubeconfig: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-admin-kubeconfig", clusterName),
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"value": data,
+		},
+	}
+
+	existing := &corev1.Secret{}
+	err = c.rosaScope.Client.Get(ctx, client.ObjectKeyFromObject(secret), existing)
+	switch {
+	case err == nil:
+		existing.Data = secret.Data
+		return c.rosaScope.Client.Update(ctx, existing)
+	case errors.IsNotFound(err):
+		return c.rosaScope.Client.Create(ctx, secret)
+	default:
+		return fmt.Errorf("failed to get existing admin kubeconfig secret: %w", err)
+	}
+}
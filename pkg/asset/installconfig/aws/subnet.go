@@ -37,6 +37,10 @@ type Subnet struct {
 	// CIDR is the subnet's CIDR block.
 	CIDR string
 
+	// IPv6CIDR is the subnet's IPv6 CIDR block, when the subnet is part of
+	// a dual-stack VPC. It is empty for IPv4-only subnets.
+	IPv6CIDR string
+
 	// ZoneType is the type of subnet's availability zone.
 	// The valid values are availability-zone, local-zone, and
 	// wavelength-zone.
@@ -53,6 +57,17 @@ type Subnet struct {
 	// Public is the flag to define the subnet public.
 	Public bool
 
+	// RouteTableID is the ID of the route table associated with the subnet,
+	// either through an explicit association or, implicitly, the VPC's main
+	// route table.
+	RouteTableID string
+
+	// NatGatewayID is the ID of the NAT Gateway used for egress by this
+	// subnet's route table, when present. It is empty for public subnets
+	// (which egress through an Internet Gateway) and for private subnets
+	// with no NAT Gateway route.
+	NatGatewayID string
+
 	// PreferredInstanceType is the preferred instance type on the subnet's zone.
 	// It's used for edge pools which usually does not have the same availability
 	// across zone groups.
@@ -165,6 +180,7 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 			return subnets, err
 		}
 		meta.Public = isPublic
+		meta.RouteTableID, meta.NatGatewayID = routeTableAndNatGatewayForSubnet(routeTables, id)
 		meta.ZoneType = *availabilityZones[meta.Zone].ZoneType
 		meta.ZoneGroup = *availabilityZones[meta.Zone].GroupName
 
@@ -203,6 +219,48 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 	return subnets, nil
 }
 
+// routeTableAndNatGatewayForSubnet finds the route table associated with the
+// subnet (explicitly, or implicitly through the VPC's main route table) and,
+// when present, the NAT Gateway it routes egress traffic through. Both are
+// precomputed here so that the generated CAPA SubnetSpec can carry them
+// directly, instead of the controller having to rediscover them at
+// reconcile time.
+func routeTableAndNatGatewayForSubnet(rt []*ec2.RouteTable, subnetID string) (routeTableID, natGatewayID string) {
+	table := findRouteTableForSubnet(rt, subnetID)
+	if table == nil {
+		return "", ""
+	}
+	routeTableID = aws.StringValue(table.RouteTableId)
+
+	for _, route := range table.Routes {
+		if strings.HasPrefix(aws.StringValue(route.NatGatewayId), "nat-") {
+			natGatewayID = aws.StringValue(route.NatGatewayId)
+			break
+		}
+	}
+	return routeTableID, natGatewayID
+}
+
+func findRouteTableForSubnet(rt []*ec2.RouteTable, subnetID string) *ec2.RouteTable {
+	for _, table := range rt {
+		for _, assoc := range table.Associations {
+			if aws.StringValue(assoc.SubnetId) == subnetID {
+				return table
+			}
+		}
+	}
+	// If there is no explicit association, the subnet is implicitly
+	// associated with the VPC's main routing table.
+	for _, table := range rt {
+		for _, assoc := range table.Associations {
+			if aws.BoolValue(assoc.Main) {
+				return table
+			}
+		}
+	}
+	return nil
+}
+
 // https://github.com/kubernetes/kubernetes/blob/9f036cd43d35a9c41d7ac4ca82398a6d0bef957b/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L3376-L3419
 func isSubnetPublic(rt []*ec2.RouteTable, subnetID string) (bool, error) {
 	var subnetTable *ec2.RouteTable
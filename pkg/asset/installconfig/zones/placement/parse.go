@@ -0,0 +1,220 @@
+package placement
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind enumerates the lexical tokens of a placement expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+// token carries the rune-offset span [start, end) it occupies in the
+// original expression, alongside its text, so the parser can slice out the
+// exact source substring spanned by any run of tokens -- this is how
+// Rule.String() reproduces the original spelling of what it was parsed
+// from instead of a normalized reprint.
+type token struct {
+	kind       tokenKind
+	text       string
+	start, end int
+}
+
+// lex tokenizes a placement expression. Identifiers may contain any
+// character other than whitespace and the structural symbols below, so
+// zone names like "us-east-1a" and region names like "us-east-1" need no
+// quoting.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			continue
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", start: i, end: i + 1})
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", start: i, end: i + 1})
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", start: i, end: i + 1})
+		case c == '&':
+			tokens = append(tokens, token{kind: tokenAnd, text: "&", start: i, end: i + 1})
+		case c == '|':
+			tokens = append(tokens, token{kind: tokenOr, text: "|", start: i, end: i + 1})
+		case c == '!':
+			tokens = append(tokens, token{kind: tokenNot, text: "!", start: i, end: i + 1})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("(),&|!", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), start: start, end: i})
+			i--
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, start: len(runes), end: len(runes)}), nil
+}
+
+// parser builds a Rule AST out of a token stream by recursive descent, with
+// binary "|" binding loosest, then binary "&", then unary "!", then
+// parenthesized groups and predicate calls. src holds the original
+// expression's runes so span() can slice out the source text a run of
+// tokens came from.
+type parser struct {
+	tokens   []token
+	pos      int
+	registry *Registry
+	src      []rune
+}
+
+// span returns the source substring spanned by tokens[fromTok:toTok]
+// inclusive, i.e. from the start of tokens[fromTok] to the end of
+// tokens[toTok].
+func (p *parser) span(fromTok, toTok int) string {
+	return string(p.src[p.tokens[fromTok].start:p.tokens[toTok].end])
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s, got %q", what, t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) parseExpr() (Rule, error) {
+	startTok := p.pos
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orRule{left: left, right: right, raw: p.span(startTok, p.pos-1)}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Rule, error) {
+	startTok := p.pos
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andRule{left: left, right: right, raw: p.span(startTok, p.pos-1)}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Rule, error) {
+	if p.peek().kind == tokenNot {
+		startTok := p.pos
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notRule{operand: operand, raw: p.span(startTok, p.pos-1)}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Rule, error) {
+	startTok := p.pos
+	switch p.peek().kind {
+	case tokenLParen:
+		p.next()
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return &groupRule{inner: inner, raw: p.span(startTok, p.pos-1)}, nil
+	case tokenIdent:
+		name := p.next().text
+		if _, err := p.expect(tokenLParen, `"("`); err != nil {
+			return nil, err
+		}
+		var args []string
+		if p.peek().kind != tokenRParen {
+			for {
+				arg, err := p.expect(tokenIdent, "argument")
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg.text)
+				if p.peek().kind != tokenComma {
+					break
+				}
+				p.next()
+			}
+		}
+		if _, err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		match, err := p.registry.resolve(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return &predicateRule{name: name, args: args, match: match, raw: p.span(startTok, p.pos-1)}, nil
+	default:
+		return nil, fmt.Errorf("unexpected token %q", p.peek().text)
+	}
+}
+
+// Parse builds a Rule from a placement expression, resolving its predicates
+// against DefaultRegistry. Every node the parser builds records the exact
+// source substring it was parsed from, so the returned Rule's String()
+// reproduces expr byte-for-byte (modulo leading/trailing whitespace around
+// the whole expression, which the lexer discards): Parse(s).String() == s
+// for any s that parses successfully, regardless of its internal
+// whitespace or argument spacing.
+func Parse(expr string) (Rule, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, registry: DefaultRegistry, src: []rune(expr)}
+	rule, err := p.parseExpr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid placement expression %q: %w", expr, err)
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("invalid placement expression %q: unexpected trailing token %q", expr, p.peek().text)
+	}
+	return rule, nil
+}
@@ -0,0 +1,123 @@
+// Package placement implements a small declarative expression language for
+// selecting zones out of a platform-discovered catalog, so that a machine
+// pool can express intent ("any zone in this region that isn't az X") rather
+// than enumerating zone names by hand.
+package placement
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ZoneInfo describes a single zone in the catalog a Rule is evaluated
+// against. Platforms build the catalog from whatever metadata they have
+// discovered; fields that a platform cannot populate are left at their zero
+// value and simply never match predicates that inspect them.
+type ZoneInfo struct {
+	// Name is the zone's name, e.g. "us-east-1a".
+	Name string
+	// Region is the parent region of the zone, e.g. "us-east-1".
+	Region string
+	// ZoneType classifies the zone, e.g. "availability-zone", "local-zone",
+	// or "wavelength-zone". Platforms are free to define their own values.
+	ZoneType string
+	// Capabilities is an arbitrary set of platform-defined tags describing
+	// the zone, e.g. instance-type availability or a service tier.
+	Capabilities sets.Set[string]
+}
+
+// Rule is a parsed placement expression. It can be evaluated against a
+// ZoneInfo, and reproduces the expression it was parsed from via String().
+type Rule interface {
+	// Matches reports whether the zone satisfies the rule.
+	Matches(zone ZoneInfo) bool
+	// String reproduces, byte-for-byte, the placement expression this Rule
+	// was parsed from: Parse(s).String() == s for any s that parses
+	// successfully, except that leading/trailing whitespace around the
+	// whole expression is trimmed (the lexer discards it before the first
+	// and after the last token, so it is never part of any node's span). A
+	// Rule built some other way than Parse falls back to a
+	// canonically-formatted reprint (single-space operator padding,
+	// ", "-joined arguments) that still reparses to an equivalent Rule.
+	String() string
+}
+
+// PredicateFunc builds a zone-matching function from the arguments a
+// predicate was called with in a placement expression, e.g. the ["a", "b"]
+// in "az(a, b)". It returns an error if the arguments are invalid.
+type PredicateFunc func(args []string) (func(zone ZoneInfo) bool, error)
+
+// Registry holds the predicate names a placement expression can call, e.g.
+// "region" or "az". Each platform registers the predicates it supports so
+// that the placement language stays platform-agnostic.
+type Registry struct {
+	predicates map[string]PredicateFunc
+}
+
+// NewRegistry returns an empty predicate Registry.
+func NewRegistry() *Registry {
+	return &Registry{predicates: map[string]PredicateFunc{}}
+}
+
+// Register adds a predicate under the given name, overwriting any predicate
+// previously registered under it.
+func (r *Registry) Register(name string, fn PredicateFunc) {
+	r.predicates[name] = fn
+}
+
+func (r *Registry) resolve(name string, args []string) (func(zone ZoneInfo) bool, error) {
+	fn, ok := r.predicates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown placement predicate %q", name)
+	}
+	return fn(args)
+}
+
+// DefaultRegistry is the Registry consulted by Parse. Platforms call
+// RegisterPredicate (or DefaultRegistry.Register directly) during package
+// initialization to make their predicates available to install-config
+// placement expressions.
+var DefaultRegistry = NewRegistry()
+
+// RegisterPredicate registers fn under name on DefaultRegistry.
+func RegisterPredicate(name string, fn PredicateFunc) {
+	DefaultRegistry.Register(name, fn)
+}
+
+// any is always registered: it matches a zone whose Name is one of args,
+// and is also the implicit rule an install-config with a plain list of zone
+// names resolves to, so that existing install-configs keep parsing.
+func init() {
+	RegisterPredicate("any", func(args []string) (func(zone ZoneInfo) bool, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("any() requires at least one zone name")
+		}
+		names := sets.New(args...)
+		return func(zone ZoneInfo) bool {
+			return names.Has(zone.Name)
+		}, nil
+	})
+}
+
+// Wrap builds the implicit rule a plain list of zone names resolves to:
+// any(zones[0], zones[1], ...). It is the fallback used when an
+// install-config supplies zone names directly instead of a placement
+// expression.
+func Wrap(zones []string) (Rule, error) {
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("no zones to wrap")
+	}
+	return Parse("any(" + joinArgs(zones) + ")")
+}
+
+func joinArgs(args []string) string {
+	out := ""
+	for i, a := range args {
+		if i > 0 {
+			out += ", "
+		}
+		out += a
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func init() {
+	RegisterPredicate("region", func(args []string) (func(zone ZoneInfo) bool, error) {
+		names := sets.New(args...)
+		return func(zone ZoneInfo) bool {
+			return names.Has(zone.Region)
+		}, nil
+	})
+	RegisterPredicate("az", func(args []string) (func(zone ZoneInfo) bool, error) {
+		names := sets.New(args...)
+		return func(zone ZoneInfo) bool {
+			return names.Has(zone.Name)
+		}, nil
+	})
+	RegisterPredicate("tier", func(args []string) (func(zone ZoneInfo) bool, error) {
+		names := sets.New(args...)
+		return func(zone ZoneInfo) bool {
+			for name := range names {
+				if zone.Capabilities.Has("tier:" + name) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	})
+	RegisterPredicate("edge", func(args []string) (func(zone ZoneInfo) bool, error) {
+		types := sets.New(args...)
+		return func(zone ZoneInfo) bool {
+			return types.Has(zone.ZoneType)
+		}, nil
+	})
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	cases := []string{
+		"region(us-east-1)",
+		"az(us-east-1a, us-east-1b)",
+		"region(us-east-1) & tier(standard)",
+		"any(us-east-1a, us-east-1b) | edge(local-zone)",
+		"!az(us-east-1e)",
+		"region(us-east-1) & !az(us-east-1e)",
+		"!(region(us-east-1) | region(us-west-2))",
+		"region(us-east-1) & (tier(standard) | tier(premium))",
+	}
+
+	for _, expr := range cases {
+		t.Run(expr, func(t *testing.T) {
+			rule, err := Parse(expr)
+			assert.NoError(t, err)
+			assert.Equal(t, expr, rule.String())
+
+			reparsed, err := Parse(rule.String())
+			assert.NoError(t, err)
+			assert.Equal(t, rule.String(), reparsed.String())
+		})
+	}
+}
+
+// TestParseRoundTrip_nonCanonicalSpacing exercises that String() reproduces
+// the source expression byte-for-byte even when its whitespace and argument
+// spacing is not what a hand-built reprint would choose -- Parse preserves
+// the original token stream rather than normalizing it.
+func TestParseRoundTrip_nonCanonicalSpacing(t *testing.T) {
+	cases := []string{
+		"region(us-east-1)&tier(standard)",
+		"region( us-east-1 )",
+		"az(us-east-1a,us-east-1b)",
+		"!az(us-east-1e)",
+		"region(us-east-1)   &   tier(standard)",
+		"az( us-east-1a , us-east-1b )",
+	}
+
+	for _, expr := range cases {
+		t.Run(expr, func(t *testing.T) {
+			rule, err := Parse(expr)
+			assert.NoError(t, err)
+			assert.Equal(t, expr, rule.String())
+
+			reparsed, err := Parse(rule.String())
+			assert.NoError(t, err)
+			assert.Equal(t, rule.String(), reparsed.String())
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"region(us-east-1",
+		"region(us-east-1) &",
+		"bogus(us-east-1)",
+		"region(us-east-1) region(us-west-2)",
+	}
+
+	for _, expr := range cases {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Parse(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	zoneA := ZoneInfo{Name: "us-east-1a", Region: "us-east-1", ZoneType: "availability-zone", Capabilities: sets.New("tier:standard")}
+	zoneE := ZoneInfo{Name: "us-east-1e", Region: "us-east-1", ZoneType: "local-zone"}
+
+	cases := []struct {
+		expr      string
+		wantZoneA bool
+		wantZoneE bool
+	}{
+		{expr: "region(us-east-1)", wantZoneA: true, wantZoneE: true},
+		{expr: "region(us-east-1) & !az(us-east-1e)", wantZoneA: true, wantZoneE: false},
+		{expr: "tier(standard)", wantZoneA: true, wantZoneE: false},
+		{expr: "edge(local-zone)", wantZoneA: false, wantZoneE: true},
+		{expr: "any(us-east-1a, us-east-1b) | edge(local-zone)", wantZoneA: true, wantZoneE: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			rule, err := Parse(tc.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantZoneA, rule.Matches(zoneA))
+			assert.Equal(t, tc.wantZoneE, rule.Matches(zoneE))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	rule, err := Wrap([]string{"us-east-1a", "us-east-1b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "any(us-east-1a, us-east-1b)", rule.String())
+	assert.True(t, rule.Matches(ZoneInfo{Name: "us-east-1a"}))
+	assert.False(t, rule.Matches(ZoneInfo{Name: "us-east-1c"}))
+
+	_, err = Wrap(nil)
+	assert.Error(t, err)
+}
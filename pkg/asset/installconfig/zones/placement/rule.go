@@ -0,0 +1,105 @@
+package placement
+
+import "strings"
+
+// predicateRule is a leaf node, e.g. "az(us-east-1e)". match is resolved
+// from the Registry at parse time; name and args are kept so String() can
+// still reproduce the call when raw is unset. raw, when set by the parser,
+// holds the exact source substring this node was parsed from, so String()
+// reproduces the original spelling (including its argument spacing) rather
+// than a normalized re-join -- see Rule.String().
+type predicateRule struct {
+	name  string
+	args  []string
+	match func(zone ZoneInfo) bool
+	raw   string
+}
+
+func (r *predicateRule) Matches(zone ZoneInfo) bool {
+	return r.match(zone)
+}
+
+func (r *predicateRule) String() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	return r.name + "(" + strings.Join(r.args, ", ") + ")"
+}
+
+// notRule negates its operand, e.g. "!az(us-east-1e)". raw, when set by the
+// parser, holds the exact source substring -- see predicateRule.
+type notRule struct {
+	operand Rule
+	raw     string
+}
+
+func (r *notRule) Matches(zone ZoneInfo) bool {
+	return !r.operand.Matches(zone)
+}
+
+func (r *notRule) String() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	return "!" + r.operand.String()
+}
+
+// andRule requires both sides to match, e.g. "region(us-east-1) & tier(standard)".
+// raw, when set by the parser, holds the exact source substring -- see
+// predicateRule.
+type andRule struct {
+	left, right Rule
+	raw         string
+}
+
+func (r *andRule) Matches(zone ZoneInfo) bool {
+	return r.left.Matches(zone) && r.right.Matches(zone)
+}
+
+func (r *andRule) String() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	return r.left.String() + " & " + r.right.String()
+}
+
+// orRule requires either side to match, e.g. "any(a, b) | edge(local-zone)".
+// raw, when set by the parser, holds the exact source substring -- see
+// predicateRule.
+type orRule struct {
+	left, right Rule
+	raw         string
+}
+
+func (r *orRule) Matches(zone ZoneInfo) bool {
+	return r.left.Matches(zone) || r.right.Matches(zone)
+}
+
+func (r *orRule) String() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	return r.left.String() + " | " + r.right.String()
+}
+
+// groupRule is an explicitly parenthesized sub-expression. It is kept as
+// its own node, rather than folded away, so that a round trip like
+// "!(region(us-east-1) | region(us-west-2))" reprints with its parens and
+// reparses into the same (and not a different, wrong) precedence. raw,
+// when set by the parser, holds the exact source substring -- see
+// predicateRule.
+type groupRule struct {
+	inner Rule
+	raw   string
+}
+
+func (r *groupRule) Matches(zone ZoneInfo) bool {
+	return r.inner.Matches(zone)
+}
+
+func (r *groupRule) String() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	return "(" + r.inner.String() + ")"
+}
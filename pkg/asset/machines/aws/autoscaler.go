@@ -0,0 +1,92 @@
+package aws
+
+import (
+	autoscalingv1beta1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+// MachineAutoscaler returns a MachineAutoscaler scaling machineSetName
+// between autoscaling.MinReplicas and autoscaling.MaxReplicas, or nil if
+// autoscaling is nil -- callers generate one of these per MachineSet
+// whose pool sets Autoscaling, alongside the fixed-replica MachineSet
+// MachineSets already emits.
+//
+// TODO(mtulio/installer#chunk5-6): call this (and ClusterAutoscaler) from
+// the manifest-generation orchestrator for every MachineSet MachineSets
+// emits -- this checkout has no pkg/asset/machines orchestrator (no
+// worker.go/master.go, no machines.go outside this package) for it to be
+// wired into yet, so nothing in this tree calls MachineAutoscaler today.
+func MachineAutoscaler(clusterID, machineSetName string, autoscaling *aws.MachinePoolAutoscaling) *autoscalingv1beta1.MachineAutoscaler {
+	if autoscaling == nil {
+		return nil
+	}
+
+	return &autoscalingv1beta1.MachineAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling.openshift.io/v1beta1",
+			Kind:       "MachineAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-machine-api",
+			Name:      machineSetName,
+			Labels: map[string]string{
+				"machine.openshift.io/cluster-api-cluster": clusterID,
+			},
+		},
+		Spec: autoscalingv1beta1.MachineAutoscalerSpec{
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			ScaleTargetRef: autoscalingv1beta1.CrossVersionObjectReference{
+				APIVersion: "machine.openshift.io/v1beta1",
+				Kind:       "MachineSet",
+				Name:       machineSetName,
+			},
+		},
+	}
+}
+
+// ClusterAutoscaler returns the cluster-wide ClusterAutoscaler singleton
+// needed for any MachineAutoscaler to take effect, sized from pools: its
+// ResourceLimits.MaxNodesTotal is the sum of every autoscaling pool's
+// MaxReplicas, and it balances similar node groups so compute pools spread
+// across zones scale up evenly rather than favoring one zone. It returns
+// nil if no pool in pools sets Autoscaling, since generating the singleton
+// without any MachineAutoscaler referencing it would do nothing.
+func ClusterAutoscaler(clusterID string, pools map[string]*types.MachinePool) *autoscalingv1beta1.ClusterAutoscaler {
+	var maxNodesTotal int32
+	var hasAutoscaling bool
+	for _, pool := range pools {
+		if pool == nil || pool.Platform.AWS == nil || pool.Platform.AWS.Autoscaling == nil {
+			continue
+		}
+		hasAutoscaling = true
+		maxNodesTotal += pool.Platform.AWS.Autoscaling.MaxReplicas
+	}
+	if !hasAutoscaling {
+		return nil
+	}
+
+	return &autoscalingv1beta1.ClusterAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling.openshift.io/v1beta1",
+			Kind:       "ClusterAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+		Spec: autoscalingv1beta1.ClusterAutoscalerSpec{
+			ResourceLimits: &autoscalingv1beta1.ResourceLimits{
+				MaxNodesTotal: pointer.Int32Ptr(maxNodesTotal),
+			},
+			ScaleDown: &autoscalingv1beta1.ScaleDownConfig{
+				Enabled:       true,
+				DelayAfterAdd: pointer.StringPtr("10m"),
+			},
+			BalanceSimilarNodeGroups: pointer.BoolPtr(true),
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestMachineAutoscaler(t *testing.T) {
+	t.Run("nil autoscaling yields no MachineAutoscaler", func(t *testing.T) {
+		assert.Nil(t, MachineAutoscaler(testClusterID, "cluster-worker-us-east-1a", nil))
+	})
+
+	t.Run("scale target references the matching MachineSet", func(t *testing.T) {
+		ma := MachineAutoscaler(testClusterID, "cluster-worker-us-east-1a", &aws.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 5})
+		if assert.NotNil(t, ma) {
+			assert.Equal(t, int32(1), ma.Spec.MinReplicas)
+			assert.Equal(t, int32(5), ma.Spec.MaxReplicas)
+			assert.Equal(t, "cluster-worker-us-east-1a", ma.Spec.ScaleTargetRef.Name)
+			assert.Equal(t, "MachineSet", ma.Spec.ScaleTargetRef.Kind)
+		}
+	})
+}
+
+func TestClusterAutoscaler(t *testing.T) {
+	t.Run("no pool autoscaling yields no ClusterAutoscaler", func(t *testing.T) {
+		pools := map[string]*types.MachinePool{
+			"worker": {Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{}}},
+		}
+		assert.Nil(t, ClusterAutoscaler(testClusterID, pools))
+	})
+
+	t.Run("max-nodes-total sums every autoscaling pool's max", func(t *testing.T) {
+		pools := map[string]*types.MachinePool{
+			"worker": {Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{
+				Autoscaling: &aws.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 6},
+			}}},
+			"edge": {Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{
+				Autoscaling: &aws.MachinePoolAutoscaling{MinReplicas: 0, MaxReplicas: 3},
+			}}},
+			"infra": {Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{}}},
+		}
+
+		ca := ClusterAutoscaler(testClusterID, pools)
+		if assert.NotNil(t, ca) {
+			assert.Equal(t, int32(9), *ca.Spec.ResourceLimits.MaxNodesTotal)
+			assert.True(t, *ca.Spec.BalanceSimilarNodeGroups)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"fmt"
+
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	manifestsaws "github.com/openshift/installer/pkg/asset/manifests/aws"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/pkg/errors"
+)
+
+// capiNamespace is where Cluster API resources are reconciled during an
+// install, mirroring the "openshift-cluster-api" namespace the installer
+// already generates the AWSCluster into (see pkg/asset/manifests/aws).
+const capiNamespace = "openshift-cluster-api"
+
+// CAPIMachineDeployment is the CAPA-flavored alternative to MachineSets: an
+// AWSMachineTemplate plus a MachineDeployment per zone, for a pool whose
+// Machines the installer manages as Cluster API resources instead of
+// machine-api MachineSets. Like MachineSets, it emits one pair per zone in
+// mpool.Zones with its own pinned FailureDomain, and splits the pool's
+// total replicas across them with distributeZoneReplicas (the same helper
+// MachineSets uses), instead of placing every replica in a single zone.
+// Within each zone, the subnet is chosen from subnets via SubnetsForPool,
+// honoring the pool's SubnetSelection.
+//
+// The "machineAPIFlavor: capi" install-config selector this is meant to be
+// gated behind belongs on the top-level install-config platform type,
+// which this checkout does not carry (pkg/types/aws has no platform.go).
+// This adds only the generation half: translating the same
+// machineProviderInput-shaped inputs MachineSets uses into the CAPA
+// AWSMachineSpec shape. It also intentionally does not emit a
+// KubeadmConfigTemplate -- OpenShift nodes bootstrap via Ignition through
+// the Machine Config Operator, not kubeadm, so each MachineDeployment's
+// Bootstrap.DataSecretName instead points at the same per-role Ignition
+// secret MachineSets' userDataSecret parameter already references.
+//
+// TODO(mtulio/installer#chunk5-5): call this from the manifest-generation
+// orchestrator once "machineAPIFlavor: capi" lands -- this checkout has no
+// pkg/asset/machines orchestrator (no worker.go/master.go, no
+// machines.go outside this package) for it to be wired into yet, so
+// nothing in this tree calls CAPIMachineDeployment today.
+func CAPIMachineDeployment(clusterID, region string, subnets []capa.SubnetSpec, pool *types.MachinePool, role, userDataSecret string, userTags map[string]string) ([]*capa.AWSMachineTemplate, []*capiv1.MachineDeployment, error) {
+	if poolPlatform := pool.Platform.Name(); poolPlatform != aws.Name {
+		return nil, nil, fmt.Errorf("non-AWS machine-pool: %q", poolPlatform)
+	}
+	mpool := pool.Platform.AWS
+	if len(mpool.Zones) == 0 {
+		return nil, nil, errors.New("at least one zone is required to create a CAPI machine deployment")
+	}
+
+	total := int64(0)
+	if pool.Replicas != nil {
+		total = *pool.Replicas
+	}
+	distribution, err := distributeZoneReplicas(mpool.Zones, total, mpool.ZoneDistribution)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to distribute replicas across zones")
+	}
+
+	var templates []*capa.AWSMachineTemplate
+	var deployments []*capiv1.MachineDeployment
+	for _, az := range mpool.Zones {
+		zoneSubnets := manifestsaws.SubnetsForPool(subnets, az, mpool.SubnetSelection)
+		if len(subnets) > 0 && len(zoneSubnets) == 0 {
+			return nil, nil, errors.Errorf("no subnet for zone %s", az)
+		}
+		var subnet string
+		if len(zoneSubnets) > 0 {
+			subnet = zoneSubnets[0].ID
+		}
+
+		name := fmt.Sprintf("%s-%s-%s", clusterID, pool.Name, az)
+
+		machineSpec := capa.AWSMachineSpec{
+			InstanceType:       mpool.InstanceType,
+			IAMInstanceProfile: fmt.Sprintf("%s-%s-profile", clusterID, role),
+			RootVolume: &capa.Volume{
+				Size:      int64(mpool.EC2RootVolume.Size),
+				Type:      capa.VolumeType(mpool.EC2RootVolume.Type),
+				IOPS:      int64(mpool.EC2RootVolume.IOPS),
+				Encrypted: pointer.BoolPtr(true),
+			},
+			AdditionalSecurityGroups: []capa.AWSResourceReference{{
+				Filters: []capa.Filter{{
+					Name:   "tag:Name",
+					Values: []string{fmt.Sprintf("%s-%s-sg", clusterID, role)},
+				}},
+			}},
+		}
+		if mpool.AMIID != "" {
+			machineSpec.AMI = capa.AMIReference{ID: pointer.StringPtr(mpool.AMIID)}
+		}
+		if subnet != "" {
+			machineSpec.Subnet = &capa.AWSResourceReference{ID: pointer.StringPtr(subnet)}
+		}
+
+		templates = append(templates, &capa.AWSMachineTemplate{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+				Kind:       "AWSMachineTemplate",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: capiNamespace,
+				Name:      name,
+			},
+			Spec: capa.AWSMachineTemplateSpec{
+				Template: capa.AWSMachineTemplateResource{
+					Spec: machineSpec,
+				},
+			},
+		})
+
+		replicas := distribution[az]
+		labels := map[string]string{
+			"cluster.x-k8s.io/cluster-name":    clusterID,
+			"cluster.x-k8s.io/deployment-name": name,
+		}
+		deployments = append(deployments, &capiv1.MachineDeployment{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "cluster.x-k8s.io/v1beta1",
+				Kind:       "MachineDeployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: capiNamespace,
+				Name:      name,
+				Labels: map[string]string{
+					"cluster.x-k8s.io/cluster-name": clusterID,
+				},
+			},
+			Spec: capiv1.MachineDeploymentSpec{
+				ClusterName: clusterID,
+				Replicas:    &replicas,
+				Selector:    metav1.LabelSelector{MatchLabels: labels},
+				Template: capiv1.MachineTemplateSpec{
+					ObjectMeta: capiv1.ObjectMeta{Labels: labels},
+					Spec: capiv1.MachineSpec{
+						ClusterName:   clusterID,
+						FailureDomain: pointer.StringPtr(az),
+						Bootstrap: capiv1.Bootstrap{
+							DataSecretName: pointer.StringPtr(userDataSecret),
+						},
+						InfrastructureRef: corev1.ObjectReference{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+							Kind:       "AWSMachineTemplate",
+							Name:       name,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return templates, deployments, nil
+}
@@ -0,0 +1,131 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestCAPIMachineDeployment(t *testing.T) {
+	pool := &types.MachinePool{
+		Name:     "worker",
+		Replicas: int64Ptr(3),
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				Zones:        []string{"us-east-1a"},
+				InstanceType: "m6i.xlarge",
+				EC2RootVolume: aws.EC2RootVolume{
+					Type: "gp3",
+					Size: 120,
+				},
+			},
+		},
+	}
+	subnets := []capa.SubnetSpec{
+		{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+	}
+
+	templates, deployments, err := CAPIMachineDeployment(testClusterID, "us-east-1", subnets, pool, "worker", "worker-user-data", nil)
+	if assert.NoError(t, err) && assert.Len(t, templates, 1) && assert.Len(t, deployments, 1) {
+		assert.Equal(t, "m6i.xlarge", templates[0].Spec.Template.Spec.InstanceType)
+		assert.Equal(t, "subnet-a", *templates[0].Spec.Template.Spec.Subnet.ID)
+		assert.Equal(t, int32(3), *deployments[0].Spec.Replicas)
+		assert.Equal(t, "AWSMachineTemplate", deployments[0].Spec.Template.Spec.InfrastructureRef.Kind)
+		assert.Equal(t, "worker-user-data", *deployments[0].Spec.Template.Spec.Bootstrap.DataSecretName)
+	}
+}
+
+func TestCAPIMachineDeployment_multiZoneSplitsReplicas(t *testing.T) {
+	pool := &types.MachinePool{
+		Name:     "worker",
+		Replicas: int64Ptr(4),
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				Zones:        []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+				InstanceType: "m6i.xlarge",
+				EC2RootVolume: aws.EC2RootVolume{
+					Type: "gp3",
+					Size: 120,
+				},
+			},
+		},
+	}
+	subnets := []capa.SubnetSpec{
+		{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-b", AvailabilityZone: "us-east-1b"},
+		{ID: "subnet-c", AvailabilityZone: "us-east-1c"},
+	}
+
+	templates, deployments, err := CAPIMachineDeployment(testClusterID, "us-east-1", subnets, pool, "worker", "worker-user-data", nil)
+	if assert.NoError(t, err) && assert.Len(t, templates, 3) && assert.Len(t, deployments, 3) {
+		wantReplicas := map[string]int32{
+			fmt.Sprintf("%s-worker-us-east-1a", testClusterID): 2,
+			fmt.Sprintf("%s-worker-us-east-1b", testClusterID): 1,
+			fmt.Sprintf("%s-worker-us-east-1c", testClusterID): 1,
+		}
+		var total int32
+		for _, d := range deployments {
+			want, ok := wantReplicas[d.Name]
+			if assert.True(t, ok, "unexpected MachineDeployment %s", d.Name) {
+				assert.Equal(t, want, *d.Spec.Replicas)
+			}
+			total += *d.Spec.Replicas
+			assert.Equal(t, d.Name, d.Spec.Template.Spec.InfrastructureRef.Name)
+		}
+		assert.Equal(t, int32(4), total)
+
+		gotAZs := map[string]bool{}
+		for _, tmpl := range templates {
+			gotAZs[tmpl.Name] = true
+		}
+		for name := range wantReplicas {
+			assert.True(t, gotAZs[name], "missing AWSMachineTemplate %s", name)
+		}
+	}
+}
+
+func TestCAPIMachineDeployment_subnetSelectionFiltersByTopology(t *testing.T) {
+	pool := &types.MachinePool{
+		Name:     "worker",
+		Replicas: int64Ptr(1),
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				Zones:           []string{"us-east-1a"},
+				InstanceType:    "m6i.xlarge",
+				SubnetSelection: aws.SubnetSelectionPublic,
+				EC2RootVolume: aws.EC2RootVolume{
+					Type: "gp3",
+					Size: 120,
+				},
+			},
+		},
+	}
+	subnets := []capa.SubnetSpec{
+		{ID: "subnet-private-a", AvailabilityZone: "us-east-1a", IsPublic: false},
+		{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+	}
+
+	templates, _, err := CAPIMachineDeployment(testClusterID, "us-east-1", subnets, pool, "worker", "worker-user-data", nil)
+	if assert.NoError(t, err) && assert.Len(t, templates, 1) {
+		assert.Equal(t, "subnet-public-a", *templates[0].Spec.Template.Spec.Subnet.ID)
+	}
+}
+
+func TestCAPIMachineDeployment_nonAWSPool(t *testing.T) {
+	pool := &types.MachinePool{Platform: types.MachinePoolPlatform{}}
+	_, _, err := CAPIMachineDeployment(testClusterID, "us-east-1", nil, pool, "worker", "worker-user-data", nil)
+	assert.ErrorContains(t, err, "non-AWS machine-pool")
+}
+
+func TestCAPIMachineDeployment_noZones(t *testing.T) {
+	pool := &types.MachinePool{Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{}}}
+	_, _, err := CAPIMachineDeployment(testClusterID, "us-east-1", nil, pool, "worker", "worker-user-data", nil)
+	assert.ErrorContains(t, err, "at least one zone is required")
+}
+
+func int64Ptr(v int64) *int64 { return &v }
@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machinev1 "github.com/openshift/api/machine/v1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/pkg/errors"
+)
+
+// ControlPlaneMachineSet returns a ControlPlaneMachineSet for the control
+// plane pool. Unlike MachineSets, which generates one MachineSet per zone,
+// a single ControlPlaneMachineSet lists every zone as an AWSFailureDomain
+// and lets the control-plane-machine-set-operator reconcile one Machine per
+// failure domain, updating them OnDelete so an operator rolls the control
+// plane one node at a time instead of all at once.
+//
+// TODO(mtulio/installer#chunk5-3): call this from the manifest-generation
+// orchestrator alongside MachineSets -- this checkout has no
+// pkg/asset/machines orchestrator (no worker.go/master.go, no
+// machines.go outside this package) for it to be wired into yet, so
+// nothing in this tree calls ControlPlaneMachineSet today.
+func ControlPlaneMachineSet(clusterID, region string, subnets map[string]string, pool *types.MachinePool, userDataSecret string, userTags map[string]string) (*machinev1.ControlPlaneMachineSet, error) {
+	if poolPlatform := pool.Platform.Name(); poolPlatform != aws.Name {
+		return nil, fmt.Errorf("non-AWS machine-pool: %q", poolPlatform)
+	}
+	mpool := pool.Platform.AWS
+	if len(mpool.Zones) == 0 {
+		return nil, errors.New("at least one zone is required to create a control-plane machine set")
+	}
+
+	failureDomains := make([]machinev1.AWSFailureDomain, 0, len(mpool.Zones))
+	for _, az := range mpool.Zones {
+		subnet, ok := subnets[az]
+		if len(subnets) > 0 && !ok {
+			return nil, errors.Errorf("no subnet for zone %s", az)
+		}
+		failureDomain := machinev1.AWSFailureDomain{
+			Placement: machinev1.AWSFailureDomainPlacement{AvailabilityZone: az},
+		}
+		if subnet != "" {
+			failureDomain.Subnet = &machinev1.AWSResourceReference{Type: machinev1.IDAWSResourceReference, ID: pointer.StringPtr(subnet)}
+		}
+		failureDomains = append(failureDomains, failureDomain)
+	}
+
+	machineProviderInput := machineProviderInput{
+		clusterID:      clusterID,
+		region:         region,
+		subnet:         subnets[mpool.Zones[0]],
+		instanceType:   mpool.InstanceType,
+		osImage:        mpool.AMIID,
+		zone:           mpool.Zones[0],
+		role:           "master",
+		userDataSecret: userDataSecret,
+		root:           &mpool.EC2RootVolume,
+		imds:           mpool.EC2Metadata,
+		userTags:       userTags,
+		privateSubnet:  true,
+	}
+	provider, err := provider(&machineProviderInput)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create provider")
+	}
+
+	replicas := int32(len(mpool.Zones))
+	selectorLabels := map[string]string{
+		"machine.openshift.io/cluster-api-machine-role": "master",
+		"machine.openshift.io/cluster-api-machine-type": "master",
+		"machine.openshift.io/cluster-api-cluster":      clusterID,
+	}
+
+	return &machinev1.ControlPlaneMachineSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "machine.openshift.io/v1",
+			Kind:       "ControlPlaneMachineSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-machine-api",
+			Name:      "cluster",
+			Labels: map[string]string{
+				"machine.openshift.io/cluster-api-cluster": clusterID,
+			},
+		},
+		Spec: machinev1.ControlPlaneMachineSetSpec{
+			Replicas: &replicas,
+			State:    machinev1.ControlPlaneMachineSetStateActive,
+			Strategy: machinev1.ControlPlaneMachineSetStrategy{
+				Type: machinev1.OnDelete,
+			},
+			Selector: metav1.LabelSelector{MatchLabels: selectorLabels},
+			Template: machinev1.ControlPlaneMachineSetTemplate{
+				MachineType: machinev1.OpenShiftMachineV1Beta1MachineType,
+				OpenShiftMachineV1Beta1Machine: &machinev1.OpenShiftMachineV1Beta1MachineTemplate{
+					FailureDomains: machinev1.FailureDomains{
+						Platform: configv1.AWSPlatformType,
+						AWS:      &failureDomains,
+					},
+					ObjectMeta: machinev1.ControlPlaneMachineSetTemplateObjectMeta{
+						Labels: selectorLabels,
+					},
+					Spec: machineapi.MachineSpec{
+						ProviderSpec: machineapi.ProviderSpec{
+							Value: &runtime.RawExtension{Object: provider},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"testing"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func validControlPlanePool() *types.MachinePool {
+	return &types.MachinePool{
+		Name: "master",
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				Zones:        []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+				InstanceType: "m6i.xlarge",
+				EC2RootVolume: aws.EC2RootVolume{
+					Type: "gp3",
+					Size: 120,
+				},
+			},
+		},
+	}
+}
+
+func TestControlPlaneMachineSet(t *testing.T) {
+	subnets := map[string]string{
+		"us-east-1a": "subnet-a",
+		"us-east-1b": "subnet-b",
+		"us-east-1c": "subnet-c",
+	}
+
+	cpms, err := ControlPlaneMachineSet(testClusterID, "us-east-1", subnets, validControlPlanePool(), "master-user-data", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, machinev1.OnDelete, cpms.Spec.Strategy.Type)
+		assert.Equal(t, int32(3), *cpms.Spec.Replicas)
+		assert.Len(t, *cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.FailureDomains.AWS, 3)
+	}
+}
+
+func TestControlPlaneMachineSet_nonAWSPool(t *testing.T) {
+	pool := &types.MachinePool{Platform: types.MachinePoolPlatform{}}
+	_, err := ControlPlaneMachineSet(testClusterID, "us-east-1", nil, pool, "master-user-data", nil)
+	assert.ErrorContains(t, err, "non-AWS machine-pool")
+}
+
+func TestControlPlaneMachineSet_noZones(t *testing.T) {
+	pool := &types.MachinePool{Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{}}}
+	_, err := ControlPlaneMachineSet(testClusterID, "us-east-1", nil, pool, "master-user-data", nil)
+	assert.ErrorContains(t, err, "at least one zone is required")
+}
+
+func TestControlPlaneMachineSet_missingSubnet(t *testing.T) {
+	pool := validControlPlanePool()
+	_, err := ControlPlaneMachineSet(testClusterID, "us-east-1", map[string]string{"us-east-1a": "subnet-a"}, pool, "master-user-data", nil)
+	assert.ErrorContains(t, err, "no subnet for zone")
+}
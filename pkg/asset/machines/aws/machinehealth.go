@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// MachineCondition summarizes a single Machine's bootstrap-time health for
+// the `wait-for install-complete` reconciliation loop: whether it has
+// reached the Running phase, and if not, the clearest reason available.
+//
+// This package has no client wiring to list Machine objects from the
+// target cluster or to decide when a wait loop should give up retrying --
+// that belongs to the command driving the loop. EvaluateMachineHealth is
+// only the decoding/classification half: given a Machine already fetched
+// by that loop, derive a structured condition from it.
+type MachineCondition struct {
+	Name    string
+	Phase   string
+	Healthy bool
+	Reason  string
+}
+
+// awsMachineProviderStatus is the subset of AWSMachineProviderStatus this
+// package decodes from Machine.Status.ProviderStatus.Raw: just enough to
+// surface the EC2 instance state reason, not the full status object.
+type awsMachineProviderStatus struct {
+	InstanceState *string                             `json:"instanceState,omitempty"`
+	Conditions    []awsMachineProviderStatusCondition `json:"conditions,omitempty"`
+}
+
+type awsMachineProviderStatusCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// EvaluateMachineHealth classifies machine into a MachineCondition.
+func EvaluateMachineHealth(machine *machineapi.Machine) MachineCondition {
+	cond := MachineCondition{Name: machine.Name}
+	if machine.Status.Phase != nil {
+		cond.Phase = *machine.Status.Phase
+	}
+	cond.Healthy = cond.Phase == "Running"
+	if cond.Healthy {
+		return cond
+	}
+
+	cond.Reason = machineErrorReason(machine)
+	if reason := awsInstanceStateReason(machine.Status.ProviderStatus); reason != "" {
+		cond.Reason = reason
+	}
+	if cond.Reason == "" {
+		cond.Reason = fmt.Sprintf("phase %q", cond.Phase)
+	}
+	return cond
+}
+
+func machineErrorReason(machine *machineapi.Machine) string {
+	switch {
+	case machine.Status.ErrorReason != nil && machine.Status.ErrorMessage != nil:
+		return fmt.Sprintf("%s: %s", *machine.Status.ErrorReason, *machine.Status.ErrorMessage)
+	case machine.Status.ErrorMessage != nil:
+		return *machine.Status.ErrorMessage
+	case machine.Status.ErrorReason != nil:
+		return string(*machine.Status.ErrorReason)
+	default:
+		return ""
+	}
+}
+
+// awsInstanceStateReason decodes raw, a Machine's ProviderStatus, for the
+// clearest available reason an instance isn't healthy yet: a non-running
+// EC2 instance state, or else the reason on the first condition listed with
+// status False and a non-empty reason. It returns "" rather than an error when raw is absent or
+// isn't an AWSMachineProviderStatus, since a Machine that hasn't been
+// provisioned yet has no provider status at all.
+func awsInstanceStateReason(raw *runtime.RawExtension) string {
+	if raw == nil || len(raw.Raw) == 0 {
+		return ""
+	}
+	var status awsMachineProviderStatus
+	if err := json.Unmarshal(raw.Raw, &status); err != nil {
+		return ""
+	}
+	if status.InstanceState != nil && *status.InstanceState != "" && *status.InstanceState != "running" {
+		return fmt.Sprintf("instance state %q", *status.InstanceState)
+	}
+	for _, c := range status.Conditions {
+		if c.Status == "False" && c.Reason != "" {
+			return c.Reason
+		}
+	}
+	return ""
+}
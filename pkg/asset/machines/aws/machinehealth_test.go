@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+func providerStatusRaw(t *testing.T, status awsMachineProviderStatus) *runtime.RawExtension {
+	t.Helper()
+	raw, err := json.Marshal(status)
+	assert.NoError(t, err)
+	return &runtime.RawExtension{Raw: raw}
+}
+
+func TestEvaluateMachineHealth(t *testing.T) {
+	t.Run("running machine is healthy", func(t *testing.T) {
+		machine := &machineapi.Machine{}
+		machine.Name = "cluster-worker-us-east-1a"
+		machine.Status.Phase = pointer.StringPtr("Running")
+
+		got := EvaluateMachineHealth(machine)
+		assert.True(t, got.Healthy)
+		assert.Empty(t, got.Reason)
+	})
+
+	t.Run("non-running instance state is surfaced", func(t *testing.T) {
+		machine := &machineapi.Machine{}
+		machine.Name = "cluster-worker-us-east-1a"
+		machine.Status.Phase = pointer.StringPtr("Provisioning")
+		machine.Status.ProviderStatus = providerStatusRaw(t, awsMachineProviderStatus{
+			InstanceState: pointer.StringPtr("pending"),
+		})
+
+		got := EvaluateMachineHealth(machine)
+		assert.False(t, got.Healthy)
+		assert.Equal(t, `instance state "pending"`, got.Reason)
+	})
+
+	t.Run("false condition reason is surfaced absent an instance state", func(t *testing.T) {
+		machine := &machineapi.Machine{}
+		machine.Status.Phase = pointer.StringPtr("Failed")
+		machine.Status.ProviderStatus = providerStatusRaw(t, awsMachineProviderStatus{
+			Conditions: []awsMachineProviderStatusCondition{
+				{Type: "InstanceReady", Status: "False", Reason: "InsufficientInstanceCapacity"},
+			},
+		})
+
+		got := EvaluateMachineHealth(machine)
+		assert.Equal(t, "InsufficientInstanceCapacity", got.Reason)
+	})
+
+	t.Run("machine error reason falls back when there is no provider status", func(t *testing.T) {
+		machine := &machineapi.Machine{}
+		machine.Status.Phase = pointer.StringPtr("Failed")
+		errReason := machineapi.InvalidConfigurationMachineError
+		machine.Status.ErrorReason = &errReason
+		machine.Status.ErrorMessage = pointer.StringPtr("instance type not supported")
+
+		got := EvaluateMachineHealth(machine)
+		assert.Equal(t, "InvalidConfiguration: instance type not supported", got.Reason)
+	})
+
+	t.Run("unknown machine falls back to its phase", func(t *testing.T) {
+		machine := &machineapi.Machine{}
+		machine.Status.Phase = pointer.StringPtr("Provisioned")
+
+		got := EvaluateMachineHealth(machine)
+		assert.Equal(t, `phase "Provisioned"`, got.Reason)
+	})
+}
@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+// machineProviderInput holds everything needed to build an
+// AWSMachineProviderConfig for a single machine.
+type machineProviderInput struct {
+	clusterID               string
+	region                  string
+	subnet                  string
+	instanceType            string
+	osImage                 string
+	zone                    string
+	role                    string
+	userDataSecret          string
+	root                    *aws.EC2RootVolume
+	imds                    aws.EC2Metadata
+	userTags                map[string]string
+	privateSubnet           bool
+	placementGroupName      string
+	placementGroupPartition *int32
+}
+
+// provider builds an AWSMachineProviderConfig for in.
+func provider(in *machineProviderInput) (*machineapi.AWSMachineProviderConfig, error) {
+	if err := validateMachineProviderInput(in); err != nil {
+		return nil, err
+	}
+
+	tags := []machineapi.TagSpecification{}
+	tagKeys := make([]string, 0, len(in.userTags))
+	for k := range in.userTags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		tags = append(tags, machineapi.TagSpecification{Name: k, Value: in.userTags[k]})
+	}
+
+	subnet := machineapi.AWSResourceReference{}
+	if in.subnet != "" {
+		subnet.ID = &in.subnet
+	}
+
+	placement := machineapi.Placement{
+		Region:           in.region,
+		AvailabilityZone: in.zone,
+	}
+	if in.placementGroupName != "" {
+		placement.PlacementGroupName = in.placementGroupName
+		placement.PlacementGroupPartition = in.placementGroupPartition
+	}
+
+	config := &machineapi.AWSMachineProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "machine.openshift.io/v1beta1",
+			Kind:       "AWSMachineProviderConfig",
+		},
+		InstanceType: in.instanceType,
+		BlockDevices: []machineapi.BlockDeviceMappingSpec{
+			{
+				EBS: &machineapi.EBSBlockDeviceSpec{
+					VolumeType: pointer.StringPtr(in.root.Type),
+					VolumeSize: pointer.Int64Ptr(int64(in.root.Size)),
+					Iops:       pointer.Int64Ptr(int64(in.root.IOPS)),
+					Encrypted:  pointer.BoolPtr(true),
+					KMSKey:     machineapi.AWSResourceReference{ARN: pointer.StringPtr(in.root.KMSKeyARN)},
+				},
+			},
+		},
+		Tags: tags,
+		IAMInstanceProfile: &machineapi.AWSResourceReference{
+			ID: pointer.StringPtr(fmt.Sprintf("%s-%s-profile", in.clusterID, in.role)),
+		},
+		UserDataSecret:    &corev1.LocalObjectReference{Name: in.userDataSecret},
+		CredentialsSecret: &corev1.LocalObjectReference{Name: "aws-cloud-credentials"},
+		Placement:         placement,
+		Subnet:            subnet,
+		SecurityGroups: []machineapi.AWSResourceReference{{
+			Filters: []machineapi.Filter{{
+				Name:   "tag:Name",
+				Values: []string{fmt.Sprintf("%s-%s-sg", in.clusterID, in.role)},
+			}},
+		}},
+	}
+	if in.osImage != "" {
+		config.AMI = machineapi.AWSResourceReference{ID: pointer.StringPtr(in.osImage)}
+	}
+
+	return config, nil
+}
+
+// validateMachineProviderInput validates the PlacementGroup configuration of
+// in: when a placement group is named and a partition is set, the partition
+// must be between 1 and 7. in carries no group strategy, so the strategy and
+// spread-per-zone checks are done by ValidatePlacementGroups, not here.
+func validateMachineProviderInput(in *machineProviderInput) error {
+	if in.placementGroupName == "" {
+		return nil
+	}
+
+	if in.placementGroupPartition != nil {
+		partition := *in.placementGroupPartition
+		if partition < 1 || partition > 7 {
+			return fmt.Errorf("placementGroupPartition must be between 1 and 7, got %d", partition)
+		}
+	}
+
+	return nil
+}
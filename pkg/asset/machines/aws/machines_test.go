@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestValidateMachineProviderInput(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        *machineProviderInput
+		expectErr string
+	}{
+		{
+			name: "no placement group",
+			in:   &machineProviderInput{},
+		},
+		{
+			name: "valid partition",
+			in: &machineProviderInput{
+				placementGroupName:      "control-plane-partitions",
+				placementGroupPartition: pointer.Int32Ptr(3),
+			},
+		},
+		{
+			name: "partition out of range",
+			in: &machineProviderInput{
+				placementGroupName:      "control-plane-partitions",
+				placementGroupPartition: pointer.Int32Ptr(8),
+			},
+			expectErr: "placementGroupPartition must be between 1 and 7",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateMachineProviderInput(tc.in)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -26,13 +26,13 @@ func MachineSets(clusterID string, region string, subnets map[string]string, poo
 	if pool.Replicas != nil {
 		total = *pool.Replicas
 	}
-	numOfAZs := int64(len(azs))
+	distribution, err := distributeZoneReplicas(azs, total, mpool.ZoneDistribution)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to distribute replicas across zones")
+	}
 	var machinesets []*machineapi.MachineSet
-	for idx, az := range mpool.Zones {
-		replicas := int32(total / numOfAZs)
-		if int64(idx) < total%numOfAZs {
-			replicas++
-		}
+	for _, az := range mpool.Zones {
+		replicas := distribution[az]
 		privateSubnet := true
 		if pool.Name == types.MachinePoolEdgeRoleName {
 			// FIXME Should check field from machinepool spec, like pool.Public, or from AZ Attribute
@@ -44,18 +44,20 @@ func MachineSets(clusterID string, region string, subnets map[string]string, poo
 			return nil, errors.Errorf("no subnet for zone %s", az)
 		}
 		machineProviderInput := machineProviderInput{
-			clusterID:      clusterID,
-			region:         region,
-			subnet:         subnet,
-			instanceType:   mpool.InstanceType,
-			osImage:        mpool.AMIID,
-			zone:           az,
-			role:           role,
-			userDataSecret: userDataSecret,
-			root:           &mpool.EC2RootVolume,
-			imds:           mpool.EC2Metadata,
-			userTags:       userTags,
-			privateSubnet:  privateSubnet,
+			clusterID:               clusterID,
+			region:                  region,
+			subnet:                  subnet,
+			instanceType:            mpool.InstanceType,
+			osImage:                 mpool.AMIID,
+			zone:                    az,
+			role:                    role,
+			userDataSecret:          userDataSecret,
+			root:                    &mpool.EC2RootVolume,
+			imds:                    mpool.EC2Metadata,
+			userTags:                userTags,
+			privateSubnet:           privateSubnet,
+			placementGroupName:      mpool.PlacementGroupName,
+			placementGroupPartition: mpool.PlacementGroupPartition,
 		}
 		provider, err := provider(&machineProviderInput)
 		if err != nil {
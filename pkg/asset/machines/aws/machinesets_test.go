@@ -146,6 +146,46 @@ func validMachineSets() []*machineapi.MachineSet {
 	return machineSets
 }
 
+// machinePoolWithPlacementGroup builds a single-zone machine pool carrying
+// a PlacementGroupName/PlacementGroupPartition, for exercising the
+// Placement propagation covered by validMachineProviderWithPlacementGroup.
+func machinePoolWithPlacementGroup(role, az, groupName string, partition *int32) *types.MachinePool {
+	return &types.MachinePool{
+		Name:     role,
+		Replicas: pointer.Int64Ptr(1),
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				Zones:        []string{az},
+				InstanceType: "m6i.xlarge",
+				EC2RootVolume: aws.EC2RootVolume{
+					Type: "gp3",
+					Size: 120,
+				},
+				PlacementGroupName:      groupName,
+				PlacementGroupPartition: partition,
+			},
+		},
+	}
+}
+
+// validMachineProviderWithPlacementGroup is validMachineProviderCompute with
+// a PlacementGroupName/PlacementGroupPartition set on Placement, the shape
+// provider() produces for a pool carrying those fields.
+func validMachineProviderWithPlacementGroup(role, region, az, groupName string, partition *int32) *machineapi.AWSMachineProviderConfig {
+	provider := validMachineProviderCompute(role, region, az)
+	provider.Placement.PlacementGroupName = groupName
+	provider.Placement.PlacementGroupPartition = partition
+	return provider
+}
+
+func validMachineSetWithPlacementGroup(role, region, az, groupName string, partition *int32) *machineapi.MachineSet {
+	mset := validMachineSetByRole(role, region, az)
+	mset.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{
+		Object: validMachineProviderWithPlacementGroup(role, region, az, groupName, partition),
+	}
+	return mset
+}
+
 func TestMachineSets(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -175,6 +215,32 @@ func TestMachineSets(t *testing.T) {
 			expectErr: true,
 			errMatch:  "invalid pool",
 		},
+		{
+			name: "compute pool with a partition placement group",
+			input: &MachineSetsInput{
+				clusterID:      testClusterID,
+				region:         "us-east-1",
+				pool:           machinePoolWithPlacementGroup("worker", "us-east-1a", "compute-partitions", pointer.Int32Ptr(2)),
+				role:           "worker",
+				userDataSecret: "userDataSecret",
+			},
+			expect: []*machineapi.MachineSet{
+				validMachineSetWithPlacementGroup("worker", "us-east-1", "us-east-1a", "compute-partitions", pointer.Int32Ptr(2)),
+			},
+		},
+		{
+			name: "control-plane pool with a spread placement group",
+			input: &MachineSetsInput{
+				clusterID:      testClusterID,
+				region:         "us-east-1",
+				pool:           machinePoolWithPlacementGroup("master", "us-east-1a", "master-spread", nil),
+				role:           "master",
+				userDataSecret: "userDataSecret",
+			},
+			expect: []*machineapi.MachineSet{
+				validMachineSetWithPlacementGroup("master", "us-east-1", "us-east-1a", "master-spread", nil),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+// PlacementGroupMetadata resolves the strategy of a pre-created EC2
+// placement group by name, erroring if it does not exist in the target
+// region. installconfig/aws.Metadata implements this.
+type PlacementGroupMetadata interface {
+	PlacementGroupStrategy(ctx context.Context, name string) (string, error)
+}
+
+// placementGroupSpreadMaxPerAZ is the maximum number of running instances
+// AWS allows a "spread" placement group to hold in any single availability
+// zone.
+const placementGroupSpreadMaxPerAZ = 7
+
+// ValidatePlacementGroups checks every pool's PlacementGroupName, when set,
+// against the pre-created EC2 placement group it names: the group must
+// already exist in the target region, PlacementGroupPartition may only be
+// set when the group's actual strategy is "partition", and a "spread"
+// group's zones must not be asked to hold more than
+// placementGroupSpreadMaxPerAZ replicas each. The per-zone count is taken
+// from distributeZoneReplicas, the same helper MachineSets/
+// CAPIMachineDeployment use to turn Replicas into actual zone counts, so a
+// pinned or weighted ZoneDistribution that concentrates replicas onto one
+// zone is checked against its real per-zone count rather than an even
+// split across all zones. Unlike Local Zone subnets or IPAM pools, the
+// installer never creates an EC2 placement group on the user's behalf --
+// machine-api-provider-aws only ever references one by name -- so a
+// missing group is always an error, never a fallback to creation.
+func ValidatePlacementGroups(ctx context.Context, meta PlacementGroupMetadata, pools map[string]*types.MachinePool) error {
+	for name, pool := range pools {
+		if pool == nil || pool.Platform.Name() != aws.Name {
+			continue
+		}
+		mpool := pool.Platform.AWS
+		if mpool == nil || mpool.PlacementGroupName == "" {
+			continue
+		}
+
+		strategy, err := meta.PlacementGroupStrategy(ctx, mpool.PlacementGroupName)
+		if err != nil {
+			return fmt.Errorf("pool %q: failed to look up placement group %q: %w", name, mpool.PlacementGroupName, err)
+		}
+		if mpool.PlacementGroupPartition != nil && strategy != string(aws.PlacementGroupStrategyPartition) {
+			return fmt.Errorf("pool %q: placementGroupPartition is set but placement group %q has strategy %q, not %q", name, mpool.PlacementGroupName, strategy, aws.PlacementGroupStrategyPartition)
+		}
+
+		if strategy == string(aws.PlacementGroupStrategySpread) && pool.Replicas != nil {
+			zones := mpool.Zones
+			if len(zones) == 0 {
+				zones = []string{""}
+			}
+			distribution, err := distributeZoneReplicas(zones, *pool.Replicas, mpool.ZoneDistribution)
+			if err != nil {
+				return fmt.Errorf("pool %q: %w", name, err)
+			}
+			for _, az := range zones {
+				if count := distribution[az]; count > placementGroupSpreadMaxPerAZ {
+					return fmt.Errorf("pool %q: placement group %q uses the %q strategy, which allows at most %d replicas per availability zone, but zone %q would get %d replicas", name, mpool.PlacementGroupName, aws.PlacementGroupStrategySpread, placementGroupSpreadMaxPerAZ, az, count)
+				}
+			}
+		}
+	}
+	return nil
+}
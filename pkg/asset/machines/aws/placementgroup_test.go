@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+type fakePlacementGroupMetadata struct {
+	strategies map[string]string
+}
+
+func (f *fakePlacementGroupMetadata) PlacementGroupStrategy(_ context.Context, name string) (string, error) {
+	strategy, ok := f.strategies[name]
+	if !ok {
+		return "", fmt.Errorf("placement group %q not found", name)
+	}
+	return strategy, nil
+}
+
+func awsPool(groupName string, partition *int32) *types.MachinePool {
+	return &types.MachinePool{
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				PlacementGroupName:      groupName,
+				PlacementGroupPartition: partition,
+			},
+		},
+	}
+}
+
+func TestValidatePlacementGroups(t *testing.T) {
+	meta := &fakePlacementGroupMetadata{strategies: map[string]string{
+		"worker-partitions": string(aws.PlacementGroupStrategyPartition),
+		"worker-spread":     string(aws.PlacementGroupStrategySpread),
+	}}
+
+	cases := []struct {
+		name      string
+		pools     map[string]*types.MachinePool
+		expectErr string
+	}{
+		{
+			name: "no placement group configured",
+			pools: map[string]*types.MachinePool{
+				"worker": {Platform: types.MachinePoolPlatform{AWS: &aws.MachinePool{}}},
+			},
+		},
+		{
+			name: "partition matches group strategy",
+			pools: map[string]*types.MachinePool{
+				"worker": awsPool("worker-partitions", pointer.Int32Ptr(3)),
+			},
+		},
+		{
+			name: "no partition set against a spread group",
+			pools: map[string]*types.MachinePool{
+				"worker": awsPool("worker-spread", nil),
+			},
+		},
+		{
+			name: "partition set against a spread group",
+			pools: map[string]*types.MachinePool{
+				"worker": awsPool("worker-spread", pointer.Int32Ptr(1)),
+			},
+			expectErr: `has strategy "spread", not "partition"`,
+		},
+		{
+			name: "group does not exist",
+			pools: map[string]*types.MachinePool{
+				"worker": awsPool("does-not-exist", nil),
+			},
+			expectErr: "not found",
+		},
+		{
+			name: "spread group within the per-AZ replica limit",
+			pools: map[string]*types.MachinePool{
+				"worker": func() *types.MachinePool {
+					pool := awsPool("worker-spread", nil)
+					pool.Replicas = pointer.Int64Ptr(14)
+					pool.Platform.AWS.Zones = []string{"us-east-1a", "us-east-1b"}
+					return pool
+				}(),
+			},
+		},
+		{
+			name: "spread group exceeds the per-AZ replica limit",
+			pools: map[string]*types.MachinePool{
+				"worker": func() *types.MachinePool {
+					pool := awsPool("worker-spread", nil)
+					pool.Replicas = pointer.Int64Ptr(15)
+					pool.Platform.AWS.Zones = []string{"us-east-1a", "us-east-1b"}
+					return pool
+				}(),
+			},
+			expectErr: `allows at most 7 replicas per availability zone, but zone "us-east-1a" would get 8 replicas`,
+		},
+		{
+			name: "spread group with no zones set exceeds the per-AZ replica limit",
+			pools: map[string]*types.MachinePool{
+				"worker": func() *types.MachinePool {
+					pool := awsPool("worker-spread", nil)
+					pool.Replicas = pointer.Int64Ptr(8)
+					return pool
+				}(),
+			},
+			expectErr: `allows at most 7 replicas per availability zone, but zone "" would get 8 replicas`,
+		},
+		{
+			name: "spread group with all replicas pinned to one zone exceeds the per-AZ replica limit",
+			pools: map[string]*types.MachinePool{
+				"worker": func() *types.MachinePool {
+					pool := awsPool("worker-spread", nil)
+					pool.Replicas = pointer.Int64Ptr(21)
+					pool.Platform.AWS.Zones = []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+					pool.Platform.AWS.ZoneDistribution = &aws.ZoneDistribution{Pinned: "us-east-1a"}
+					return pool
+				}(),
+			},
+			expectErr: `allows at most 7 replicas per availability zone, but zone "us-east-1a" would get 21 replicas`,
+		},
+		{
+			name: "spread group with a weighted ZoneDistribution within the per-AZ replica limit",
+			pools: map[string]*types.MachinePool{
+				"worker": func() *types.MachinePool {
+					pool := awsPool("worker-spread", nil)
+					pool.Replicas = pointer.Int64Ptr(21)
+					pool.Platform.AWS.Zones = []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+					pool.Platform.AWS.ZoneDistribution = &aws.ZoneDistribution{
+						Mode: aws.ZoneDistributionExplicit,
+						Weights: map[string]int32{
+							"us-east-1a": 7,
+							"us-east-1b": 7,
+							"us-east-1c": 7,
+						},
+					}
+					return pool
+				}(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePlacementGroups(context.Background(), meta, tc.pools)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
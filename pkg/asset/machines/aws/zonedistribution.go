@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+// distributeZoneReplicas returns how many replicas belong in each of azs,
+// summing to total. When dist is nil, replicas are split evenly across azs
+// in round-robin order, the long-standing default. Otherwise dist.Pinned,
+// or dist.Weights interpreted per dist.Mode, decides the split; every zone
+// named by dist must also appear in azs.
+func distributeZoneReplicas(azs []string, total int64, dist *aws.ZoneDistribution) (map[string]int32, error) {
+	if dist == nil {
+		return distributeZoneReplicasRoundRobin(azs, total), nil
+	}
+
+	if dist.Pinned != "" {
+		if !containsZone(azs, dist.Pinned) {
+			return nil, fmt.Errorf("zoneDistribution.pinned zone %q is not one of this pool's zones %v", dist.Pinned, azs)
+		}
+		out := make(map[string]int32, len(azs))
+		for _, az := range azs {
+			out[az] = 0
+		}
+		out[dist.Pinned] = int32(total)
+		return out, nil
+	}
+
+	for zone := range dist.Weights {
+		if !containsZone(azs, zone) {
+			return nil, fmt.Errorf("zoneDistribution references zone %q which is not one of this pool's zones %v", zone, azs)
+		}
+	}
+
+	if dist.Mode == aws.ZoneDistributionWeighted {
+		return distributeZoneReplicasWeighted(azs, total, dist.Weights), nil
+	}
+
+	out := make(map[string]int32, len(azs))
+	sum := int64(0)
+	for _, az := range azs {
+		out[az] = dist.Weights[az]
+		sum += int64(dist.Weights[az])
+	}
+	if sum != total {
+		return nil, fmt.Errorf("zoneDistribution explicit replica counts sum to %d, want %d", sum, total)
+	}
+	return out, nil
+}
+
+// distributeZoneReplicasRoundRobin is the original even distribution: every
+// zone gets total/len(azs) replicas, with the remainder given one at a time
+// to the first zones in azs.
+func distributeZoneReplicasRoundRobin(azs []string, total int64) map[string]int32 {
+	out := make(map[string]int32, len(azs))
+	numOfAZs := int64(len(azs))
+	if numOfAZs == 0 {
+		return out
+	}
+	for idx, az := range azs {
+		replicas := int32(total / numOfAZs)
+		if int64(idx) < total%numOfAZs {
+			replicas++
+		}
+		out[az] = replicas
+	}
+	return out
+}
+
+// distributeZoneReplicasWeighted divides total proportionally across azs by
+// weight (default weight 1 for a zone with no entry in weights), assigning
+// the remainder left over from truncation to the zones with the largest
+// fractional share first.
+func distributeZoneReplicasWeighted(azs []string, total int64, weights map[string]int32) map[string]int32 {
+	totalWeight := int32(0)
+	for _, az := range azs {
+		totalWeight += weightOrDefault(weights, az)
+	}
+	if totalWeight == 0 {
+		return make(map[string]int32, len(azs))
+	}
+
+	type remainder struct {
+		az   string
+		frac float64
+	}
+	out := make(map[string]int32, len(azs))
+	remainders := make([]remainder, 0, len(azs))
+	assigned := int64(0)
+	for _, az := range azs {
+		exact := float64(total) * float64(weightOrDefault(weights, az)) / float64(totalWeight)
+		base := int32(exact)
+		out[az] = base
+		assigned += int64(base)
+		remainders = append(remainders, remainder{az: az, frac: exact - float64(base)})
+	}
+
+	sort.SliceStable(remainders, func(i, j int) bool { return remainders[i].frac > remainders[j].frac })
+	for i := int64(0); i < total-assigned; i++ {
+		out[remainders[i%int64(len(remainders))].az]++
+	}
+	return out
+}
+
+func weightOrDefault(weights map[string]int32, az string) int32 {
+	if w, ok := weights[az]; ok && w != 0 {
+		return w
+	}
+	return 1
+}
+
+func containsZone(azs []string, zone string) bool {
+	for _, az := range azs {
+		if az == zone {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func Test_distributeZoneReplicas(t *testing.T) {
+	azs := []string{"a", "b", "c"}
+
+	cases := []struct {
+		name      string
+		total     int64
+		dist      *aws.ZoneDistribution
+		want      map[string]int32
+		expectErr string
+	}{
+		{
+			name:  "nil distribution falls back to round robin",
+			total: 4,
+			want:  map[string]int32{"a": 2, "b": 1, "c": 1},
+		},
+		{
+			name:  "pinned sends every replica to one zone",
+			total: 3,
+			dist:  &aws.ZoneDistribution{Pinned: "b"},
+			want:  map[string]int32{"a": 0, "b": 3, "c": 0},
+		},
+		{
+			name:      "pinned zone not in pool",
+			total:     3,
+			dist:      &aws.ZoneDistribution{Pinned: "d"},
+			expectErr: `zoneDistribution.pinned zone "d" is not one of this pool's zones`,
+		},
+		{
+			name:  "explicit counts",
+			total: 5,
+			dist:  &aws.ZoneDistribution{Weights: map[string]int32{"a": 3, "b": 2}},
+			want:  map[string]int32{"a": 3, "b": 2, "c": 0},
+		},
+		{
+			name:      "explicit counts must sum to total",
+			total:     5,
+			dist:      &aws.ZoneDistribution{Weights: map[string]int32{"a": 3, "b": 1}},
+			expectErr: "zoneDistribution explicit replica counts sum to 4, want 5",
+		},
+		{
+			name:      "explicit references unknown zone",
+			total:     1,
+			dist:      &aws.ZoneDistribution{Weights: map[string]int32{"d": 1}},
+			expectErr: `zoneDistribution references zone "d" which is not one of this pool's zones`,
+		},
+		{
+			name:  "weighted splits proportionally",
+			total: 6,
+			dist:  &aws.ZoneDistribution{Mode: aws.ZoneDistributionWeighted, Weights: map[string]int32{"a": 3, "b": 2, "c": 1}},
+			want:  map[string]int32{"a": 3, "b": 2, "c": 1},
+		},
+		{
+			name:  "weighted unweighted zones default to weight 1",
+			total: 4,
+			dist:  &aws.ZoneDistribution{Mode: aws.ZoneDistributionWeighted, Weights: map[string]int32{"a": 3}},
+			want:  map[string]int32{"a": 2, "b": 1, "c": 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := distributeZoneReplicas(azs, tc.total, tc.dist)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			var sum int32
+			for _, n := range got {
+				sum += n
+			}
+			assert.Equal(t, int32(tc.total), sum)
+		})
+	}
+}
+
+func Test_distributeZoneReplicas_noZones(t *testing.T) {
+	got, err := distributeZoneReplicas(nil, 3, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int32{}, got)
+}
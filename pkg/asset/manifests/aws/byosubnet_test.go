@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/aws"
+)
+
+func TestByoSubnetSpec(t *testing.T) {
+	cases := []struct {
+		name                string
+		subnet              aws.Subnet
+		expectRouteTableID  *string
+		expectNatGatewayID  *string
+		expectIPv6CidrBlock string
+		expectIsIPv6        bool
+	}{
+		{
+			name: "no route table discovered",
+			subnet: aws.Subnet{
+				ID:   "subnet-a",
+				CIDR: "10.0.1.0/24",
+				Zone: &aws.Zone{Name: "a"},
+			},
+		},
+		{
+			name: "private subnet with nat gateway",
+			subnet: aws.Subnet{
+				ID:           "subnet-a",
+				CIDR:         "10.0.1.0/24",
+				Zone:         &aws.Zone{Name: "a"},
+				RouteTableID: "rtb-1",
+				NatGatewayID: "nat-1",
+			},
+			expectRouteTableID: strPtr("rtb-1"),
+			expectNatGatewayID: strPtr("nat-1"),
+		},
+		{
+			name: "public subnet with no nat gateway",
+			subnet: aws.Subnet{
+				ID:           "subnet-a",
+				CIDR:         "10.0.1.0/24",
+				Zone:         &aws.Zone{Name: "a"},
+				Public:       true,
+				RouteTableID: "rtb-2",
+			},
+			expectRouteTableID: strPtr("rtb-2"),
+		},
+		{
+			name: "dual-stack subnet carries its IPv6 CIDR through",
+			subnet: aws.Subnet{
+				ID:       "subnet-a",
+				CIDR:     "10.0.1.0/24",
+				IPv6CIDR: "fd00:1234:5678::/64",
+				Zone:     &aws.Zone{Name: "a"},
+			},
+			expectIPv6CidrBlock: "fd00:1234:5678::/64",
+			expectIsIPv6:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := byoSubnetSpec(tc.subnet)
+			assert.Equal(t, tc.expectRouteTableID, spec.RouteTableID)
+			assert.Equal(t, tc.expectNatGatewayID, spec.NatGatewayID)
+			assert.Equal(t, tc.expectIPv6CidrBlock, spec.IPv6CidrBlock)
+			assert.Equal(t, tc.expectIsIPv6, spec.IsIPv6)
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// validateIPAMPoolMachineNetwork rejects combining ipamPool with an
+// explicit secondary MachineNetwork CIDR. IPAM pools already provide their
+// own mechanism (SecondaryPool) for additional address space, so a
+// secondary MachineNetwork entry would be silently ignored by the IPAM
+// allocation path otherwise.
+func validateIPAMPoolMachineNetwork(ic *installconfig.InstallConfig) error {
+	if ic.Config.AWS.IPAMPool == nil {
+		return nil
+	}
+	cidrs, err := ipv4MachineNetworkCIDRsFromInstallConfig(ic)
+	if err != nil {
+		return fmt.Errorf("failed to parse machine network CIDRs: %w", err)
+	}
+	if len(cidrs) > 1 {
+		return fmt.Errorf("ipamPool cannot be combined with an explicit secondary MachineNetwork CIDR")
+	}
+	return nil
+}
+
+// ipamPoolReconcileTimeout bounds how long we wait for CAPA to allocate the
+// VPC CIDR from the configured IPAM pool before failing the install.
+const ipamPoolReconcileTimeout = 5 * time.Minute
+
+// setSubnetsManagedVPCFromIPAMPool configures the CAPI NetworkSpec.VPC and
+// NetworkSpec.Subnets to source their CIDRs from an AWS VPC IPAM pool
+// rather than CIDR blocks computed from install-config.yaml. Each emitted
+// SubnetSpec carries an IPAMPool reference instead of a CidrBlock; CAPA
+// claims the concrete per-subnet CIDR at reconcile time, so no literal
+// CIDR is ever written here. Zone ordering and subnet-ID naming mirror
+// setSubnetsManagedVPC so the rest of the pipeline does not need to care
+// which allocation mode produced the subnets.
+func setSubnetsManagedVPCFromIPAMPool(in *zonesInput) error {
+	pool := in.InstallConfig.Config.AWS.IPAMPool
+
+	in.Cluster.Spec.NetworkSpec.VPC = capa.VPCSpec{
+		IPAMPool: subnetIPAMPoolRef(pool),
+	}
+
+	out, err := extractZonesFromInstallConfig(in)
+	if err != nil {
+		return fmt.Errorf("failed to get availability zones: %w", err)
+	}
+
+	isPublishingExternal := in.InstallConfig.Config.Publish == types.ExternalPublishingStrategy
+	allAvailabilityZones := out.AvailabilityZones()
+	allEdgeZones := out.EdgeZones()
+
+	// edgePool lets edge (Local Zone or Wavelength Zone) subnets draw from a
+	// dedicated secondary pool, mirroring how a secondary MachineNetwork CIDR
+	// is dedicated to edge zones in the non-IPAM allocation path.
+	edgePool := pool
+	if pool.SecondaryPool != nil {
+		edgePool = pool.SecondaryPool
+	}
+
+	for _, zone := range allAvailabilityZones {
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			AvailabilityZone: zone,
+			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
+			IsPublic:         false,
+			IPAMPool:         subnetIPAMPoolRef(pool),
+		})
+	}
+	if isPublishingExternal {
+		for _, zone := range allAvailabilityZones {
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				AvailabilityZone: zone,
+				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
+				IsPublic:         true,
+				IPAMPool:         subnetIPAMPoolRef(pool),
+			})
+		}
+	}
+
+	for _, zone := range allEdgeZones {
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			AvailabilityZone: zone,
+			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
+			IsPublic:         false,
+			IsWavelengthZone: out.wavelengthZones.Has(zone),
+			IPAMPool:         subnetIPAMPoolRef(edgePool),
+		})
+	}
+	if isPublishingExternal {
+		for _, zone := range allEdgeZones {
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				AvailabilityZone: zone,
+				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
+				IsPublic:         true,
+				IsWavelengthZone: out.wavelengthZones.Has(zone),
+				IPAMPool:         subnetIPAMPoolRef(edgePool),
+			})
+		}
+	}
+
+	return nil
+}
+
+// subnetIPAMPoolRef builds the CAPA IPAMPool reference shared by the VPC
+// and every subnet drawing from the given install-config IPAM pool.
+func subnetIPAMPoolRef(pool *awstypes.IPAMPool) *capa.IPAMPool {
+	return &capa.IPAMPool{
+		ID:            pool.PoolID,
+		NetmaskLength: pool.NetmaskLength,
+	}
+}
+
+// WaitForIPAMPoolAllocation polls the reconciled AWSCluster until CAPA has
+// claimed a VPC CIDR from the configured IPAM pool, so that subnet CIDR
+// calculation (normally done up-front in setSubnetsManagedVPC) can run
+// against the allocated block instead of a user-supplied one.
+func WaitForIPAMPoolAllocation(ctx context.Context, pool *awstypes.IPAMPool, getVPCCIDR func(ctx context.Context) (string, error)) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, ipamPoolReconcileTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		cidr, err := getVPCCIDR(ctx)
+		if err == nil && cidr != "" {
+			return cidr, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("timed out waiting for VPC CIDR to be allocated from IPAM pool %s: %w", pool.PoolID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
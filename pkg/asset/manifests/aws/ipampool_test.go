@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+
+	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestSetSubnetsManagedVPCFromIPAMPool(t *testing.T) {
+	ic := stubInstallConfig()
+	ic.Config = &types.InstallConfig{
+		Publish: types.ExternalPublishingStrategy,
+		AWS: &awstypes.Platform{
+			IPAMPool: &awstypes.IPAMPool{
+				PoolID:        "ipam-pool-1",
+				NetmaskLength: 24,
+			},
+		},
+		ControlPlane: &types.MachinePool{
+			Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{Zones: []string{"a", "b"}}},
+		},
+	}
+
+	in := &zonesInput{
+		ClusterID:     stubClusterID(),
+		InstallConfig: ic,
+		Cluster: &capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "infraId",
+				Namespace: capiutils.Namespace,
+			},
+			Spec: capa.AWSClusterSpec{},
+		},
+	}
+
+	err := setSubnetsManagedVPCFromIPAMPool(in)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &capa.IPAMPool{ID: "ipam-pool-1", NetmaskLength: 24}, in.Cluster.Spec.NetworkSpec.VPC.IPAMPool)
+	assert.Empty(t, in.Cluster.Spec.NetworkSpec.VPC.CidrBlock)
+
+	for _, subnet := range in.Cluster.Spec.NetworkSpec.Subnets {
+		assert.Empty(t, subnet.CidrBlock, "subnet %s must not carry a literal CIDR when IPAM is configured", subnet.ID)
+		assert.NotNil(t, subnet.IPAMPool, "subnet %s must carry an IPAMPool reference when IPAM is configured", subnet.ID)
+		assert.Equal(t, "ipam-pool-1", subnet.IPAMPool.ID)
+	}
+	assert.Len(t, in.Cluster.Spec.NetworkSpec.Subnets, 4)
+}
+
+func TestSetSubnetsManagedVPCFromIPAMPoolWithSecondaryPool(t *testing.T) {
+	ic := stubInstallConfig()
+	ic.Config = &types.InstallConfig{
+		AWS: &awstypes.Platform{
+			IPAMPool: &awstypes.IPAMPool{
+				PoolID:        "ipam-pool-1",
+				NetmaskLength: 24,
+				SecondaryPool: &awstypes.IPAMPool{
+					PoolID:        "ipam-pool-edge",
+					NetmaskLength: 26,
+				},
+			},
+		},
+		ControlPlane: &types.MachinePool{
+			Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{Zones: []string{"a"}}},
+		},
+		Compute: []types.MachinePool{
+			{
+				Name: "edge",
+				Platform: types.MachinePoolPlatform{
+					AWS: &awstypes.MachinePool{Zones: []string{"edge-a"}},
+				},
+			},
+		},
+	}
+
+	in := &zonesInput{
+		ClusterID:     stubClusterID(),
+		InstallConfig: ic,
+		Cluster: &capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "infraId",
+				Namespace: capiutils.Namespace,
+			},
+			Spec: capa.AWSClusterSpec{},
+		},
+	}
+
+	err := setSubnetsManagedVPCFromIPAMPool(in)
+	assert.NoError(t, err)
+	// The VPC's own CIDR is always claimed from the primary pool; the
+	// secondary pool only backs edge-zone subnets, checked below.
+	assert.Equal(t, &capa.IPAMPool{ID: "ipam-pool-1", NetmaskLength: 24}, in.Cluster.Spec.NetworkSpec.VPC.IPAMPool)
+
+	for _, subnet := range in.Cluster.Spec.NetworkSpec.Subnets {
+		assert.Empty(t, subnet.CidrBlock)
+		switch subnet.AvailabilityZone {
+		case "edge-a":
+			assert.Equal(t, "ipam-pool-edge", subnet.IPAMPool.ID)
+		default:
+			assert.Equal(t, "ipam-pool-1", subnet.IPAMPool.ID)
+		}
+	}
+}
+
+func TestValidateIPAMPoolMachineNetwork(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       *types.InstallConfig
+		expectErr string
+	}{
+		{
+			name: "no ipamPool configured",
+			cfg: &types.InstallConfig{
+				AWS: &awstypes.Platform{},
+				Networking: &types.Networking{
+					MachineNetwork: []types.MachineNetworkEntry{
+						{CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR)},
+						{CIDR: *ipnet.MustParseCIDR("10.1.0.0/20")},
+					},
+				},
+			},
+		},
+		{
+			name: "ipamPool with only the primary MachineNetwork entry",
+			cfg: &types.InstallConfig{
+				AWS: &awstypes.Platform{
+					IPAMPool: &awstypes.IPAMPool{PoolID: "ipam-pool-1", NetmaskLength: 24},
+				},
+				Networking: &types.Networking{
+					MachineNetwork: []types.MachineNetworkEntry{
+						{CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR)},
+					},
+				},
+			},
+		},
+		{
+			name: "ipamPool with an explicit secondary MachineNetwork CIDR",
+			cfg: &types.InstallConfig{
+				AWS: &awstypes.Platform{
+					IPAMPool: &awstypes.IPAMPool{PoolID: "ipam-pool-1", NetmaskLength: 24},
+				},
+				Networking: &types.Networking{
+					MachineNetwork: []types.MachineNetworkEntry{
+						{CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR)},
+						{CIDR: *ipnet.MustParseCIDR("10.1.0.0/20")},
+					},
+				},
+			},
+			expectErr: "ipamPool cannot be combined",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := stubInstallConfig()
+			ic.Config = tc.cfg
+			err := validateIPAMPoolMachineNetwork(ic)
+			if tc.expectErr != "" {
+				assert.Error(t, err)
+				assert.Regexp(t, tc.expectErr, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/zones/placement"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// init registers the AWS placement predicates so that install-config
+// `placement` expressions can call them: region, az, tier, edge, and
+// capability. See placement.ZoneInfo for what each one inspects.
+func init() {
+	placement.RegisterPredicate("region", func(args []string) (func(placement.ZoneInfo) bool, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("region() requires at least one region name")
+		}
+		regions := sets.New(args...)
+		return func(zone placement.ZoneInfo) bool {
+			return regions.Has(zone.Region)
+		}, nil
+	})
+
+	placement.RegisterPredicate("az", func(args []string) (func(placement.ZoneInfo) bool, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("az() requires at least one zone name")
+		}
+		names := sets.New(args...)
+		return func(zone placement.ZoneInfo) bool {
+			return names.Has(zone.Name)
+		}, nil
+	})
+
+	placement.RegisterPredicate("edge", func(args []string) (func(placement.ZoneInfo) bool, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("edge() requires at least one zone type, e.g. local-zone or wavelength-zone")
+		}
+		zoneTypes := sets.New(args...)
+		return func(zone placement.ZoneInfo) bool {
+			return zoneTypes.Has(zone.ZoneType)
+		}, nil
+	})
+
+	placement.RegisterPredicate("capability", func(args []string) (func(placement.ZoneInfo) bool, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("capability() requires at least one capability name")
+		}
+		names := sets.New(args...)
+		return func(zone placement.ZoneInfo) bool {
+			return zone.Capabilities.Intersection(names).Len() > 0
+		}, nil
+	})
+
+	// tier is a thin convenience wrapper over capability, namespacing its
+	// argument so "tier(standard)" and "capability(standard)" can't collide
+	// with an unrelated capability of the same name.
+	placement.RegisterPredicate("tier", func(args []string) (func(placement.ZoneInfo) bool, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("tier() requires at least one tier name")
+		}
+		tiers := sets.New[string]()
+		for _, arg := range args {
+			tiers.Insert("tier:" + arg)
+		}
+		return func(zone placement.ZoneInfo) bool {
+			return zone.Capabilities.Intersection(tiers).Len() > 0
+		}, nil
+	})
+}
+
+// SetDefaultConfigPlacement resolves rule against catalog and assigns every
+// matching zone to the appropriate internal set: zones classified as Local
+// Zone or Wavelength Zone always flow into localZones/wavelengthZones, same
+// as the explicit-zone-list path, and Outposts into outpostZones, regardless
+// of which pool the rule came from. Every other zone goes to
+// controlPlaneZones or computeZones when pool is the control plane or
+// compute pool; for any other pool name it is not assigned.
+func (zo *zonesCAPI) SetDefaultConfigPlacement(pool string, rule placement.Rule, catalog []placement.ZoneInfo) {
+	for _, zone := range catalog {
+		if !rule.Matches(zone) {
+			continue
+		}
+		switch zone.ZoneType {
+		case awstypes.WavelengthZoneType:
+			zo.wavelengthZones.Insert(zone.Name)
+		case awstypes.LocalZoneType:
+			zo.localZones.Insert(zone.Name)
+		case awstypes.OutpostZoneType:
+			zo.outpostZones.Insert(zone.Name)
+		default:
+			switch pool {
+			case types.MachinePoolControlPlaneRoleName:
+				zo.controlPlaneZones.Insert(zone.Name)
+			case types.MachinePoolComputeRoleName:
+				zo.computeZones.Insert(zone.Name)
+			}
+		}
+	}
+}
+
+// buildPlacementCatalog assembles the zone catalog placement rules are
+// evaluated against: every region AZ discovered from AWS metadata, plus
+// every edge zone referenced anywhere in the install-config's edge compute
+// pool. There is no AWS metadata lookup for Local Zone/Wavelength Zone
+// membership in this code path, so an edge zone can only be matched by a
+// placement rule if some pool already names it.
+func buildPlacementCatalog(in *zonesInput) []placement.ZoneInfo {
+	var region string
+	if in.InstallConfig.Config.AWS != nil {
+		region = in.InstallConfig.Config.AWS.Region
+	}
+
+	catalog := make([]placement.ZoneInfo, 0, len(in.ZonesInRegion))
+	seen := sets.New[string]()
+	for _, zone := range in.ZonesInRegion {
+		seen.Insert(zone)
+		catalog = append(catalog, placement.ZoneInfo{
+			Name:     zone,
+			Region:   region,
+			ZoneType: awstypes.RegularZoneType,
+		})
+	}
+
+	for _, pool := range in.InstallConfig.Config.Compute {
+		if pool.Name != types.MachinePoolEdgeRoleName || pool.Platform.AWS == nil {
+			continue
+		}
+		for _, zone := range pool.Platform.AWS.Zones {
+			if seen.Has(zone) {
+				continue
+			}
+			seen.Insert(zone)
+			catalog = append(catalog, placement.ZoneInfo{
+				Name:     zone,
+				Region:   region,
+				ZoneType: classifyEdgeZoneType(zone),
+			})
+		}
+	}
+	return catalog
+}
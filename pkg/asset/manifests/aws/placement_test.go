@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/zones/placement"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func Test_registeredPredicates(t *testing.T) {
+	regularZone := placement.ZoneInfo{Name: "us-east-1a", Region: "us-east-1", ZoneType: awstypes.RegularZoneType}
+	localZone := placement.ZoneInfo{Name: "us-west-2-lax-1a", Region: "us-west-2", ZoneType: awstypes.LocalZoneType}
+	taggedZone := placement.ZoneInfo{Name: "us-east-1b", Region: "us-east-1", ZoneType: awstypes.RegularZoneType, Capabilities: sets.New("tier:standard")}
+
+	cases := []struct {
+		name      string
+		expr      string
+		wantMatch map[string]bool
+	}{
+		{
+			name:      "region",
+			expr:      "region(us-east-1)",
+			wantMatch: map[string]bool{"regular": true, "local": false},
+		},
+		{
+			name:      "az",
+			expr:      "az(us-east-1a)",
+			wantMatch: map[string]bool{"regular": true, "local": false},
+		},
+		{
+			name:      "edge",
+			expr:      "edge(local-zone)",
+			wantMatch: map[string]bool{"regular": false, "local": true},
+		},
+		{
+			name:      "tier",
+			expr:      "tier(standard)",
+			wantMatch: map[string]bool{"regular": false, "tagged": true},
+		},
+		{
+			name:      "capability",
+			expr:      "capability(tier:standard)",
+			wantMatch: map[string]bool{"regular": false, "tagged": true},
+		},
+	}
+
+	zones := map[string]placement.ZoneInfo{"regular": regularZone, "local": localZone, "tagged": taggedZone}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := placement.Parse(tc.expr)
+			assert.NoError(t, err)
+			for zoneName, want := range tc.wantMatch {
+				assert.Equal(t, want, rule.Matches(zones[zoneName]), "zone %q", zoneName)
+			}
+		})
+	}
+}
+
+func Test_buildPlacementCatalog(t *testing.T) {
+	ic := stubInstallConfig()
+	ic.Config = &types.InstallConfig{
+		AWS: &awstypes.Platform{Region: "us-east-1"},
+		Compute: []types.MachinePool{
+			{
+				Name: types.MachinePoolEdgeRoleName,
+				Platform: types.MachinePoolPlatform{
+					AWS: &awstypes.MachinePool{Zones: []string{"us-east-1-wl1-bos-wlz-1", "us-west-2-lax-1a"}},
+				},
+			},
+		},
+	}
+	in := &zonesInput{
+		InstallConfig: ic,
+		ZonesInRegion: []string{"us-east-1a", "us-east-1b"},
+	}
+
+	catalog := buildPlacementCatalog(in)
+
+	byName := map[string]placement.ZoneInfo{}
+	for _, zone := range catalog {
+		byName[zone.Name] = zone
+	}
+
+	assert.Len(t, catalog, 4)
+	assert.Equal(t, awstypes.RegularZoneType, byName["us-east-1a"].ZoneType)
+	assert.Equal(t, awstypes.RegularZoneType, byName["us-east-1b"].ZoneType)
+	assert.Equal(t, awstypes.WavelengthZoneType, byName["us-east-1-wl1-bos-wlz-1"].ZoneType)
+	assert.Equal(t, awstypes.LocalZoneType, byName["us-west-2-lax-1a"].ZoneType)
+	assert.Equal(t, "us-east-1", byName["us-east-1a"].Region)
+}
+
+func Test_SetDefaultConfigPlacement(t *testing.T) {
+	catalog := []placement.ZoneInfo{
+		{Name: "us-east-1a", Region: "us-east-1", ZoneType: awstypes.RegularZoneType},
+		{Name: "us-east-1b", Region: "us-east-1", ZoneType: awstypes.RegularZoneType},
+		{Name: "us-west-2-lax-1a", Region: "us-west-2", ZoneType: awstypes.LocalZoneType},
+		{Name: "us-east-1-wl1-bos-wlz-1", Region: "us-east-1", ZoneType: awstypes.WavelengthZoneType},
+		{Name: "us-east-1a-outpost-1", Region: "us-east-1", ZoneType: awstypes.OutpostZoneType},
+	}
+
+	out := &zonesCAPI{
+		controlPlaneZones: sets.New[string](),
+		computeZones:      sets.New[string](),
+		localZones:        sets.New[string](),
+		wavelengthZones:   sets.New[string](),
+		outpostZones:      sets.New[string](),
+	}
+
+	rule, err := placement.Parse("region(us-east-1)")
+	assert.NoError(t, err)
+	out.SetDefaultConfigPlacement(types.MachinePoolControlPlaneRoleName, rule, catalog)
+
+	assert.Equal(t, sets.New("us-east-1a", "us-east-1b"), out.controlPlaneZones)
+	assert.Equal(t, sets.New("us-east-1-wl1-bos-wlz-1"), out.wavelengthZones)
+	assert.Equal(t, sets.New("us-east-1a-outpost-1"), out.outpostZones)
+	assert.Equal(t, sets.New[string](), out.localZones)
+
+	rule, err = placement.Parse("edge(local-zone)")
+	assert.NoError(t, err)
+	out.SetDefaultConfigPlacement(types.MachinePoolComputeRoleName, rule, catalog)
+	assert.Equal(t, sets.New("us-west-2-lax-1a"), out.localZones)
+	assert.Equal(t, sets.New[string](), out.computeZones)
+}
+
+func Test_EdgeZoneKind(t *testing.T) {
+	zo := &zonesCAPI{
+		controlPlaneZones: sets.New[string](),
+		computeZones:      sets.New[string](),
+		localZones:        sets.New("us-west-2-lax-1a"),
+		wavelengthZones:   sets.New("us-east-1-wl1-bos-wlz-1"),
+		outpostZones:      sets.New("us-east-1a-outpost-1"),
+	}
+
+	assert.Equal(t, ZoneKindLocal, zo.EdgeZoneKind("us-west-2-lax-1a"))
+	assert.Equal(t, ZoneKindWavelength, zo.EdgeZoneKind("us-east-1-wl1-bos-wlz-1"))
+	assert.Equal(t, ZoneKindOutpost, zo.EdgeZoneKind("us-east-1a-outpost-1"))
+	assert.Equal(t, ZoneKindUnknown, zo.EdgeZoneKind("us-east-1a"))
+
+	assert.ElementsMatch(t, []string{"us-west-2-lax-1a"}, zo.EdgeZonesByKind(ZoneKindLocal))
+	assert.ElementsMatch(t, []string{"us-east-1-wl1-bos-wlz-1"}, zo.EdgeZonesByKind(ZoneKindWavelength))
+	assert.ElementsMatch(t, []string{"us-east-1a-outpost-1"}, zo.EdgeZonesByKind(ZoneKindOutpost))
+	assert.Nil(t, zo.EdgeZonesByKind(ZoneKindAvailability))
+
+	assert.ElementsMatch(t, []string{"us-west-2-lax-1a", "us-east-1-wl1-bos-wlz-1", "us-east-1a-outpost-1"}, zo.EdgeZones())
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"hash/fnv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// SetReplicaSpread records how many replicas a pool needs and which
+// SpreadPolicy to distribute them with, so a later DistributeReplicas call
+// can divide them across the pool's assigned zones.
+func (zo *zonesCAPI) SetReplicaSpread(pool string, replicas int64, policy awstypes.SpreadPolicy) {
+	if zo.replicas == nil {
+		zo.replicas = map[string]int64{}
+	}
+	if zo.spreadPolicies == nil {
+		zo.spreadPolicies = map[string]awstypes.SpreadPolicy{}
+	}
+	zo.replicas[pool] = replicas
+	zo.spreadPolicies[pool] = policy
+}
+
+// DistributeReplicas returns, for the given pool, how many replicas belong
+// in each of its assigned zones, according to the SpreadPolicy recorded for
+// it via SetReplicaSpread (SpreadPack if none was recorded). Downstream
+// MachineSet generation can emit one MachineSet per returned zone, sized to
+// its replica count.
+func (zo *zonesCAPI) DistributeReplicas(pool string) map[string]int {
+	var candidates []string
+	switch pool {
+	case types.MachinePoolControlPlaneRoleName:
+		candidates = sets.List(zo.controlPlaneZones)
+	case types.MachinePoolComputeRoleName:
+		candidates = sets.List(zo.computeZones)
+	}
+	if len(candidates) == 0 {
+		return map[string]int{}
+	}
+
+	replicas := int(zo.replicas[pool])
+	policy := zo.spreadPolicies[pool]
+
+	switch policy {
+	case awstypes.SpreadBalanced:
+		return distributeAcrossZones(candidates, replicas, clusterNameHashOffset(zo.clusterName, len(candidates)))
+	case awstypes.SpreadMaxAZ:
+		return distributeAcrossZones(candidates, replicas, 0)
+	default:
+		return distributeAcrossZones(candidates[:1], replicas, 0)
+	}
+}
+
+// distributeAcrossZones rounds replicas across min(replicas, len(candidates))
+// zones, drawn from candidates starting at startOffset (wrapping around),
+// one replica at a time, so counts differ by at most one between zones.
+func distributeAcrossZones(candidates []string, replicas int, startOffset int) map[string]int {
+	out := map[string]int{}
+	if replicas <= 0 || len(candidates) == 0 {
+		return out
+	}
+
+	zoneCount := replicas
+	if zoneCount > len(candidates) {
+		zoneCount = len(candidates)
+	}
+	selected := make([]string, zoneCount)
+	for i := range selected {
+		selected[i] = candidates[(startOffset+i)%len(candidates)]
+	}
+
+	for i := 0; i < replicas; i++ {
+		out[selected[i%zoneCount]]++
+	}
+	return out
+}
+
+// clusterNameHashOffset derives a stable starting index into an n-zone
+// candidate list from the cluster name, so that SpreadBalanced picks the
+// same zones across repeated defaulting runs for the same cluster instead
+// of depending on map iteration order.
+func clusterNameHashOffset(clusterName string, n int) int {
+	if n == 0 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(clusterName))
+	return int(h.Sum32() % uint32(n))
+}
@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func Test_zonesCAPI_DistributeReplicas(t *testing.T) {
+	candidateZones := []string{"a", "b", "c"}
+
+	sum := func(dist map[string]int) int {
+		total := 0
+		for _, n := range dist {
+			total += n
+		}
+		return total
+	}
+
+	tests := []struct {
+		name     string
+		policy   awstypes.SpreadPolicy
+		replicas int64
+		want     map[string]int
+	}{
+		{
+			name:     "pack 1 replica",
+			policy:   awstypes.SpreadPack,
+			replicas: 1,
+			want:     map[string]int{"a": 1},
+		},
+		{
+			name:     "pack 6 replicas stay in one zone",
+			policy:   awstypes.SpreadPack,
+			replicas: 6,
+			want:     map[string]int{"a": 6},
+		},
+		{
+			name:     "balanced 1 replica uses one zone",
+			policy:   awstypes.SpreadBalanced,
+			replicas: 1,
+		},
+		{
+			name:     "balanced 2 replicas use two zones",
+			policy:   awstypes.SpreadBalanced,
+			replicas: 2,
+		},
+		{
+			name:     "balanced 3 replicas use all three zones evenly",
+			policy:   awstypes.SpreadBalanced,
+			replicas: 3,
+		},
+		{
+			name:     "balanced 6 replicas spread evenly over three zones",
+			policy:   awstypes.SpreadBalanced,
+			replicas: 6,
+			want:     map[string]int{"a": 2, "b": 2, "c": 2},
+		},
+		{
+			name:     "maxAZ 1 replica uses one zone",
+			policy:   awstypes.SpreadMaxAZ,
+			replicas: 1,
+			want:     map[string]int{"a": 1},
+		},
+		{
+			name:     "maxAZ 2 replicas use the first two zones",
+			policy:   awstypes.SpreadMaxAZ,
+			replicas: 2,
+			want:     map[string]int{"a": 1, "b": 1},
+		},
+		{
+			name:     "maxAZ 3 replicas use all three zones",
+			policy:   awstypes.SpreadMaxAZ,
+			replicas: 3,
+			want:     map[string]int{"a": 1, "b": 1, "c": 1},
+		},
+		{
+			name:     "maxAZ 6 replicas spread evenly over three zones",
+			policy:   awstypes.SpreadMaxAZ,
+			replicas: 6,
+			want:     map[string]int{"a": 2, "b": 2, "c": 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			zo := &zonesCAPI{
+				computeZones: sets.New(candidateZones...),
+				clusterName:  "infra-id",
+			}
+			zo.SetReplicaSpread(types.MachinePoolComputeRoleName, tc.replicas, tc.policy)
+
+			got := zo.DistributeReplicas(types.MachinePoolComputeRoleName)
+			assert.Equal(t, int(tc.replicas), sum(got))
+			if tc.want != nil {
+				assert.Equal(t, tc.want, got)
+			}
+
+			// Determinism: a second zonesCAPI built from the same cluster
+			// name and inputs must distribute identically.
+			zo2 := &zonesCAPI{
+				computeZones: sets.New(candidateZones...),
+				clusterName:  "infra-id",
+			}
+			zo2.SetReplicaSpread(types.MachinePoolComputeRoleName, tc.replicas, tc.policy)
+			assert.Equal(t, got, zo2.DistributeReplicas(types.MachinePoolComputeRoleName))
+		})
+	}
+}
+
+func Test_zonesCAPI_DistributeReplicas_noCandidates(t *testing.T) {
+	zo := &zonesCAPI{computeZones: sets.Set[string]{}}
+	zo.SetReplicaSpread(types.MachinePoolComputeRoleName, 3, awstypes.SpreadMaxAZ)
+	assert.Equal(t, map[string]int{}, zo.DistributeReplicas(types.MachinePoolComputeRoleName))
+}
+
+func Test_zonesCAPI_DistributeReplicas_unset(t *testing.T) {
+	zo := &zonesCAPI{computeZones: sets.New("a", "b")}
+	assert.Equal(t, map[string]int{}, zo.DistributeReplicas(types.MachinePoolComputeRoleName))
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func stubPoolSubnets() []capa.SubnetSpec {
+	return []capa.SubnetSpec{
+		{ID: "private-a", AvailabilityZone: "us-east-1a", IsPublic: false},
+		{ID: "public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "private-b", AvailabilityZone: "us-east-1b", IsPublic: false},
+	}
+}
+
+func TestSubnetsForPool(t *testing.T) {
+	cases := []struct {
+		name      string
+		zone      string
+		selection awstypes.SubnetSelection
+		expectIDs []string
+	}{
+		{
+			name:      "private only",
+			zone:      "us-east-1a",
+			selection: awstypes.SubnetSelectionPrivate,
+			expectIDs: []string{"private-a"},
+		},
+		{
+			name:      "public only",
+			zone:      "us-east-1a",
+			selection: awstypes.SubnetSelectionPublic,
+			expectIDs: []string{"public-a"},
+		},
+		{
+			name:      "all",
+			zone:      "us-east-1a",
+			selection: awstypes.SubnetSelectionAll,
+			expectIDs: []string{"private-a", "public-a"},
+		},
+		{
+			name:      "no zone match",
+			zone:      "us-east-1c",
+			selection: awstypes.SubnetSelectionAll,
+			expectIDs: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SubnetsForPool(stubPoolSubnets(), tc.zone, tc.selection)
+			var gotIDs []string
+			for _, s := range got {
+				gotIDs = append(gotIDs, s.ID)
+			}
+			assert.Equal(t, tc.expectIDs, gotIDs)
+		})
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/asset/installconfig/zones/placement"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
 	utilscidr "github.com/openshift/installer/pkg/asset/manifests/capiutils/cidr"
 	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 type subnetsInput struct {
@@ -62,7 +65,69 @@ func (zin *zonesInput) GatherSubnetsFromMetadata(ctx context.Context) (err error
 type zonesCAPI struct {
 	controlPlaneZones sets.Set[string]
 	computeZones      sets.Set[string]
-	edgeZones         sets.Set[string]
+	localZones        sets.Set[string]
+	wavelengthZones   sets.Set[string]
+	outpostZones      sets.Set[string]
+
+	// clusterName, replicas, and spreadPolicies back DistributeReplicas; see
+	// SetReplicaSpread.
+	clusterName    string
+	replicas       map[string]int64
+	spreadPolicies map[string]awstypes.SpreadPolicy
+}
+
+// ZoneKind classifies a zone by the kind of AWS infrastructure it runs on,
+// so downstream subnet/NLB/route-table handling can branch on it instead of
+// re-deriving it from the zone name.
+type ZoneKind string
+
+const (
+	// ZoneKindAvailability is a regular, region-hosted Availability Zone.
+	ZoneKindAvailability ZoneKind = "availability"
+	// ZoneKindLocal is an AWS Local Zone.
+	ZoneKindLocal ZoneKind = "local"
+	// ZoneKindWavelength is an AWS Wavelength Zone.
+	ZoneKindWavelength ZoneKind = "wavelength"
+	// ZoneKindOutpost is an AWS Outpost. This tree has no source of Outpost
+	// membership for a zone name (Outposts share their parent region's zone
+	// name and are only distinguished by Outpost ARN), so no zone is ever
+	// classified into it today; it exists so EdgeZoneKind/EdgeZonesByKind
+	// have a stable answer once that metadata becomes available.
+	ZoneKindOutpost ZoneKind = "outpost"
+	// ZoneKindUnknown is returned for a zone name zonesCAPI has not seen.
+	ZoneKindUnknown ZoneKind = "unknown"
+)
+
+// EdgeZoneKind reports the ZoneKind of a zone previously inserted into the
+// edge pool's sets (localZones, wavelengthZones, or outpostZones), or
+// ZoneKindUnknown if it was not.
+func (zo *zonesCAPI) EdgeZoneKind(name string) ZoneKind {
+	switch {
+	case zo.localZones.Has(name):
+		return ZoneKindLocal
+	case zo.wavelengthZones.Has(name):
+		return ZoneKindWavelength
+	case zo.outpostZones.Has(name):
+		return ZoneKindOutpost
+	default:
+		return ZoneKindUnknown
+	}
+}
+
+// EdgeZonesByKind returns the sorted list of edge zone names classified as
+// kind. Passing ZoneKindAvailability or ZoneKindUnknown always returns nil,
+// since those are not edge pool kinds.
+func (zo *zonesCAPI) EdgeZonesByKind(kind ZoneKind) []string {
+	switch kind {
+	case ZoneKindLocal:
+		return sets.List(zo.localZones)
+	case ZoneKindWavelength:
+		return sets.List(zo.wavelengthZones)
+	case ZoneKindOutpost:
+		return sets.List(zo.outpostZones)
+	default:
+		return nil
+	}
 }
 
 // AvailabilityZones returns a sorted union of Availability Zones defined
@@ -71,10 +136,22 @@ func (zo *zonesCAPI) AvailabilityZones() []string {
 	return sets.List(zo.controlPlaneZones.Union(zo.computeZones))
 }
 
-// EdgeZones returns a sorted union of Local Zones or Wavelength Zones
-// defined in the zone attribute in the edge compute pool.
+// EdgeZones returns a sorted union of Local Zones, Wavelength Zones, and
+// Outposts defined in the zone attribute in the edge compute pool.
 func (zo *zonesCAPI) EdgeZones() []string {
-	return sets.List(zo.edgeZones)
+	return sets.List(zo.localZones.Union(zo.wavelengthZones).Union(zo.outpostZones))
+}
+
+// LocalZones returns a sorted list of Local Zones defined in the zone
+// attribute in the edge compute pool.
+func (zo *zonesCAPI) LocalZones() []string {
+	return sets.List(zo.localZones)
+}
+
+// WavelengthZones returns a sorted list of Wavelength Zones defined in the
+// zone attribute in the edge compute pool.
+func (zo *zonesCAPI) WavelengthZones() []string {
+	return sets.List(zo.wavelengthZones)
 }
 
 // SetAvailabilityZones insert the zone to the given compute pool, and to
@@ -130,12 +207,25 @@ func setSubnets(ctx context.Context, in *zonesInput) error {
 		return fmt.Errorf("failed to get AWSCluster config")
 	}
 	if len(in.InstallConfig.Config.AWS.Subnets) > 0 {
+		if in.InstallConfig.Config.AWS.IPAMPool != nil {
+			return fmt.Errorf("ipamPool cannot be combined with BYO subnets")
+		}
 		if err := in.GatherSubnetsFromMetadata(ctx); err != nil {
 			return fmt.Errorf("failed to get subnets from metadata: %w", err)
 		}
 		return setSubnetsBYOVPC(in)
 	}
 
+	if in.InstallConfig.Config.AWS.IPAMPool != nil {
+		if err := validateIPAMPoolMachineNetwork(in.InstallConfig); err != nil {
+			return err
+		}
+		if err := in.GatherZonesFromMetadata(ctx); err != nil {
+			return fmt.Errorf("failed to get availability zones from metadata: %w", err)
+		}
+		return setSubnetsManagedVPCFromIPAMPool(in)
+	}
+
 	if err := in.GatherZonesFromMetadata(ctx); err != nil {
 		return fmt.Errorf("failed to get availability zones from metadata: %w", err)
 	}
@@ -149,41 +239,65 @@ func setSubnets(ctx context.Context, in *zonesInput) error {
 // TODO: create support to mock AWS API calls in the unit tests, so we can merge
 // the methods GatherSubnetsFromMetadata() into this.
 func setSubnetsBYOVPC(in *zonesInput) error {
+	if err := validateNoMixedFamilySubnets(in.Subnets); err != nil {
+		return fmt.Errorf("invalid BYO subnets: %w", err)
+	}
+	if err := validateZonesInput(in); err != nil {
+		return fmt.Errorf("ambiguous zone configuration: %w", err)
+	}
+	if err := validateDualStackSubnets(in.Subnets); err != nil {
+		return fmt.Errorf("invalid BYO subnets: %w", err)
+	}
+
 	in.Cluster.Spec.NetworkSpec.VPC = capa.VPCSpec{
 		ID: in.Subnets.vpc,
 	}
 	for _, subnet := range in.Subnets.privateSubnets {
-		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
-			ID:               subnet.ID,
-			CidrBlock:        subnet.CIDR,
-			AvailabilityZone: subnet.Zone.Name,
-			IsPublic:         subnet.Public,
-		})
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, byoSubnetSpec(subnet))
 	}
 
 	for _, subnet := range in.Subnets.publicSubnets {
-		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
-			ID:               subnet.ID,
-			CidrBlock:        subnet.CIDR,
-			AvailabilityZone: subnet.Zone.Name,
-			IsPublic:         subnet.Public,
-		})
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, byoSubnetSpec(subnet))
 	}
 
 	// edgeSubnets are subnet created on AWS Local Zones or Wavelength Zone,
 	// discovered by ID and zone-type attribute.
 	for _, subnet := range in.Subnets.edgeSubnets {
-		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
-			ID:               subnet.ID,
-			CidrBlock:        subnet.CIDR,
-			AvailabilityZone: subnet.Zone.Name,
-			IsPublic:         subnet.Public,
-		})
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, byoSubnetSpec(subnet))
 	}
 
 	return nil
 }
 
+// byoSubnetSpec converts a discovered BYO subnet into a CAPA SubnetSpec,
+// carrying over its precomputed route table association and, when present,
+// the NAT Gateway it egresses through, so the CAPA controller does not need
+// to rediscover them at reconcile time.
+func byoSubnetSpec(subnet aws.Subnet) capa.SubnetSpec {
+	spec := capa.SubnetSpec{
+		ID:               subnet.ID,
+		CidrBlock:        subnet.CIDR,
+		AvailabilityZone: subnet.Zone.Name,
+		IsPublic:         subnet.Public,
+	}
+	if subnet.RouteTableID != "" {
+		routeTableID := subnet.RouteTableID
+		spec.RouteTableID = &routeTableID
+	}
+	if subnet.NatGatewayID != "" {
+		natGatewayID := subnet.NatGatewayID
+		spec.NatGatewayID = &natGatewayID
+	}
+	if subnet.IPv6CIDR != "" {
+		spec.IPv6CidrBlock = subnet.IPv6CIDR
+		spec.IsIPv6 = true
+	}
+	if subnet.ZoneType == awstypes.WavelengthZoneType {
+		spec.IsWavelengthZone = true
+	}
+	return spec
+}
+
 // setSubnetsManagedVPC creates the CAPI NetworkSpec.VPC and the NetworkSpec.Subnets,
 // setting the desired zones from install-config.yaml in the managed
 // VPC deployment, when specified, otherwise default zones are set from
@@ -206,7 +320,86 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 
 	mainCIDR := capiutils.CIDRFromInstallConfig(in.InstallConfig)
 	in.Cluster.Spec.NetworkSpec.VPC = capa.VPCSpec{
-		CidrBlock: mainCIDR.String(),
+		CidrBlock:         mainCIDR.String(),
+		AvailabilityZones: allAvailabilityZones,
+	}
+	if platform := in.InstallConfig.Config.AWS; platform != nil && platform.AvailabilityZoneUsageLimit != nil {
+		in.Cluster.Spec.NetworkSpec.VPC.AvailabilityZoneUsageLimit = platform.AvailabilityZoneUsageLimit
+	}
+
+	// ipv4MachineNetworkCIDRs mirrors every IPv4 entry of Networking.MachineNetwork,
+	// in order: index 0 is the primary CIDR already captured above as mainCIDR,
+	// and any further entries are secondary VPC CIDR blocks (e.g. to carve edge
+	// zones out of a separate range, or to extend an exhausted primary).
+	ipv4MachineNetworkCIDRs, err := ipv4MachineNetworkCIDRsFromInstallConfig(in.InstallConfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse machine network CIDRs: %w", err)
+	}
+	if len(ipv4MachineNetworkCIDRs) > 1 {
+		secondary := make([]capa.VpcCidrBlock, 0, len(ipv4MachineNetworkCIDRs)-1)
+		for _, cidr := range ipv4MachineNetworkCIDRs[1:] {
+			secondary = append(secondary, capa.VpcCidrBlock{IPv4CidrBlock: cidr.String()})
+		}
+		in.Cluster.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = secondary
+	}
+
+	edgeCIDROverride, err := edgeZoneMachineNetworkCIDR(in.InstallConfig, ipv4MachineNetworkCIDRs)
+	if err != nil {
+		return fmt.Errorf("failed to resolve edge zone machine network: %w", err)
+	}
+
+	ipv6CIDR, isDualStack := ipv6CIDRFromInstallConfig(in.InstallConfig)
+	var ipv6PrivateSubnets, ipv6PublicSubnets, ipv6EdgePrivateSubnets, ipv6EdgePublicSubnets []*net.IPNet
+	if isDualStack {
+		// One /64 per zone, ordered private (availability-zone), then public
+		// (availability-zone), then edge private, then edge public -- mirroring
+		// the IPv4 ordering above -- leaving one free block for Day-2 expansion.
+		numIPv6Subnets := len(allAvailabilityZones)
+		if isPublishingExternal {
+			numIPv6Subnets += len(allAvailabilityZones)
+		}
+		if len(allEdgeZones) > 0 {
+			numIPv6Subnets += len(allEdgeZones)
+			if isPublishingExternal {
+				numIPv6Subnets += len(allEdgeZones)
+			}
+		}
+		numIPv6Subnets++
+
+		ipv6Subnets, err := utilscidr.SplitIntoSubnetsIPv6(ipv6CIDR.String(), numIPv6Subnets)
+		if err != nil {
+			return fmt.Errorf("unable to generate IPv6 CIDR blocks for dual-stack subnets: %w", err)
+		}
+		offset := 0
+		ipv6PrivateSubnets, offset = ipv6Subnets[offset:offset+len(allAvailabilityZones)], offset+len(allAvailabilityZones)
+		if isPublishingExternal {
+			ipv6PublicSubnets, offset = ipv6Subnets[offset:offset+len(allAvailabilityZones)], offset+len(allAvailabilityZones)
+		}
+		if len(allEdgeZones) > 0 {
+			ipv6EdgePrivateSubnets, offset = ipv6Subnets[offset:offset+len(allEdgeZones)], offset+len(allEdgeZones)
+			if isPublishingExternal {
+				ipv6EdgePublicSubnets, offset = ipv6Subnets[offset:offset+len(allEdgeZones)], offset+len(allEdgeZones)
+			}
+		}
+
+		in.Cluster.Spec.NetworkSpec.VPC.IPv6 = &capa.IPv6{
+			CidrBlock: ipv6CIDR.String(),
+		}
+		// TODO(dual-stack): wire EgressOnlyInternetGateway creation once CAPA
+		// exposes a reconciler hook for it on the VPCSpec.
+	}
+
+	var alloc *awstypes.SubnetCIDRAllocation
+	if platform := in.InstallConfig.Config.AWS; platform != nil {
+		alloc = platform.SubnetCIDRAllocation
+	}
+	if alloc != nil {
+		switch alloc.Strategy {
+		case awstypes.SubnetCIDRAllocationStrategyByMask:
+			return setSubnetsManagedVPCByMask(in, mainCIDR, isPublishingExternal, allAvailabilityZones, allEdgeZones)
+		case awstypes.SubnetCIDRAllocationStrategyExplicit:
+			return setSubnetsManagedVPCExplicit(in, mainCIDR, isPublishingExternal, allAvailabilityZones, allEdgeZones)
+		}
 	}
 
 	// Base subnets considering only private zones, leaving one free block to allow
@@ -218,9 +411,12 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 		numSubnets++
 	}
 
-	// Edge subnets consumes one CIDR block from private CIDR, slicing it
-	// into smaller depending on the amount edge zones added to install config.
-	if len(allEdgeZones) > 0 {
+	// Edge subnets consume one CIDR block from the private CIDR, slicing it
+	// into smaller blocks depending on the amount of edge zones added to
+	// install config, unless a dedicated secondary MachineNetwork CIDR was
+	// pinned to the edge pool, in which case edge zones carve out of that
+	// block instead and the primary CIDR is left untouched.
+	if len(allEdgeZones) > 0 && edgeCIDROverride == nil {
 		numSubnets++
 	}
 
@@ -233,7 +429,11 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 	var edgeCIDR string
 	if len(allEdgeZones) > 0 {
 		publicCIDR = privateCIDRs[len(allAvailabilityZones)].String()
-		edgeCIDR = privateCIDRs[len(allAvailabilityZones)+1].String()
+		if edgeCIDROverride != nil {
+			edgeCIDR = edgeCIDROverride.String()
+		} else {
+			edgeCIDR = privateCIDRs[len(allAvailabilityZones)+1].String()
+		}
 	}
 
 	var publicCIDRs []*net.IPNet
@@ -254,19 +454,29 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 	}
 
 	for idxCIDR, zone := range allAvailabilityZones {
-		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+		subnet := capa.SubnetSpec{
 			AvailabilityZone: zone,
 			CidrBlock:        privateCIDRs[idxCIDR].String(),
 			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
 			IsPublic:         false,
-		})
+		}
+		if isDualStack && idxCIDR < len(ipv6PrivateSubnets) {
+			subnet.IPv6CidrBlock = ipv6PrivateSubnets[idxCIDR].String()
+			subnet.IsIPv6 = true
+		}
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, subnet)
 		if isPublishingExternal {
-			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			pubSubnet := capa.SubnetSpec{
 				AvailabilityZone: zone,
 				CidrBlock:        publicCIDRs[idxCIDR].String(),
 				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
 				IsPublic:         true,
-			})
+			}
+			if isDualStack && idxCIDR < len(ipv6PublicSubnets) {
+				pubSubnet.IPv6CidrBlock = ipv6PublicSubnets[idxCIDR].String()
+				pubSubnet.IsIPv6 = true
+			}
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, pubSubnet)
 		}
 	}
 
@@ -296,19 +506,37 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 
 		// Create subnets from zone pool with type local-zone or wavelength-zone (edge zones)
 		for idxCIDR, zone := range allEdgeZones {
-			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			isWavelengthZone := out.wavelengthZones.Has(zone)
+			edgeSubnet := capa.SubnetSpec{
 				AvailabilityZone: zone,
 				CidrBlock:        edgeCIDRs[idxCIDR].String(),
 				ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
 				IsPublic:         false,
-			})
+				IsWavelengthZone: isWavelengthZone,
+			}
+			if isDualStack && idxCIDR < len(ipv6EdgePrivateSubnets) {
+				edgeSubnet.IPv6CidrBlock = ipv6EdgePrivateSubnets[idxCIDR].String()
+				edgeSubnet.IsIPv6 = true
+			}
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, edgeSubnet)
 			if isPublishingExternal {
-				in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				edgePubSubnet := capa.SubnetSpec{
 					AvailabilityZone: zone,
 					CidrBlock:        edgeCIDRs[len(allEdgeZones)+idxCIDR].String(),
 					ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
 					IsPublic:         true,
-				})
+					IsWavelengthZone: isWavelengthZone,
+				}
+				// Wavelength public subnets egress through a carrier gateway
+				// instead of the standard internet gateway that Local Zone
+				// (and regular availability-zone) public subnets use; the
+				// CAPA reconciler provisions and associates it based on the
+				// IsWavelengthZone marker above.
+				if isDualStack && idxCIDR < len(ipv6EdgePublicSubnets) {
+					edgePubSubnet.IPv6CidrBlock = ipv6EdgePublicSubnets[idxCIDR].String()
+					edgePubSubnet.IsIPv6 = true
+				}
+				in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, edgePubSubnet)
 			}
 		}
 	}
@@ -316,12 +544,255 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 	return nil
 }
 
+// validateZonesInput cross-checks the three signals that independently
+// express which Availability Zones a BYO VPC cluster is meant to span:
+//  1. per-pool `zones` lists in controlPlane/compute,
+//  2. defaultMachinePlatform.zones, and
+//  3. the AZ coverage of the discovered BYO subnets.
+//
+// Today these signals can silently disagree, producing a CAPA
+// NetworkSpec.Subnets that only partially matches what the user asked for
+// (e.g. a 3-zone control plane landing on a 2-zone BYO VPC). This fails
+// fast instead, naming exactly which signals conflict.
+func validateZonesInput(in *zonesInput) error {
+	if in.Subnets == nil || in.InstallConfig == nil || in.InstallConfig.Config == nil {
+		return nil
+	}
+
+	byoZones := sets.New[string]()
+	for _, group := range []aws.Subnets{in.Subnets.privateSubnets, in.Subnets.publicSubnets, in.Subnets.edgeSubnets} {
+		for _, subnet := range group {
+			byoZones.Insert(subnet.Zone.Name)
+		}
+	}
+	if byoZones.Len() == 0 {
+		return nil
+	}
+
+	cfg := in.InstallConfig.Config
+	check := func(poolName string, configured []string) error {
+		if len(configured) == 0 {
+			return nil
+		}
+		configuredZones := sets.New(configured...)
+		if !configuredZones.IsSubset(byoZones) {
+			return fmt.Errorf("%s zones %v are not a subset of the BYO subnets' Availability Zones %v", poolName, sets.List(configuredZones), sets.List(byoZones))
+		}
+		return nil
+	}
+
+	if cfg.ControlPlane != nil && cfg.ControlPlane.Platform.AWS != nil {
+		if err := check("controlPlane", cfg.ControlPlane.Platform.AWS.Zones); err != nil {
+			return err
+		}
+	}
+	for _, pool := range cfg.Compute {
+		if pool.Platform.AWS == nil {
+			continue
+		}
+		if err := check(fmt.Sprintf("compute pool %q", pool.Name), pool.Platform.AWS.Zones); err != nil {
+			return err
+		}
+	}
+	if cfg.AWS != nil && cfg.AWS.DefaultMachinePlatform != nil {
+		if err := check("defaultMachinePlatform", cfg.AWS.DefaultMachinePlatform.Zones); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SubnetsForPool filters the BYO VPC subnets generated onto the CAPA
+// NetworkSpec down to the ones a given machine pool is allowed to use in
+// its zone(s), honoring the pool's subnetSelection (public, private, or
+// all). This mirrors OPENSHIFT_INSTALL_AWS_PUBLIC_ONLY, but scoped to a
+// single machine pool instead of the whole cluster, so e.g. NAT-less
+// workers can be mixed with private control-plane nodes in the same BYO VPC.
+func SubnetsForPool(subnets []capa.SubnetSpec, zone string, selection awstypes.SubnetSelection) []capa.SubnetSpec {
+	var out []capa.SubnetSpec
+	for _, subnet := range subnets {
+		if subnet.AvailabilityZone != zone {
+			continue
+		}
+		switch selection {
+		case awstypes.SubnetSelectionPublic:
+			if !subnet.IsPublic {
+				continue
+			}
+		case awstypes.SubnetSelectionPrivate:
+			if subnet.IsPublic {
+				continue
+			}
+		case awstypes.SubnetSelectionAll, "":
+			// no filtering; any subnet in the zone is eligible.
+		}
+		out = append(out, subnet)
+	}
+	return out
+}
+
+// validateNoMixedFamilySubnets ensures that a single subnet family (IPv4 or
+// IPv6) is used consistently across all discovered subnets' primary CIDR
+// field. This only concerns legacy single-stack subnets whose sole CIDR is
+// IPv6; dual-stack subnets always carry an IPv4 CIDR plus a separate
+// IPv6CIDR, and are handled by validateDualStackSubnets instead.
+func validateNoMixedFamilySubnets(subnets *subnetsInput) error {
+	if subnets == nil {
+		return nil
+	}
+
+	sawIPv4, sawIPv6 := false, false
+	for _, group := range []aws.Subnets{subnets.privateSubnets, subnets.publicSubnets, subnets.edgeSubnets} {
+		for _, subnet := range group {
+			ip, _, err := net.ParseCIDR(subnet.CIDR)
+			if err != nil {
+				return fmt.Errorf("unable to parse CIDR %q for subnet %s: %w", subnet.CIDR, subnet.ID, err)
+			}
+			if ip.To4() != nil {
+				sawIPv4 = true
+			} else {
+				sawIPv6 = true
+			}
+		}
+	}
+
+	if sawIPv4 && sawIPv6 {
+		return fmt.Errorf("mixing IPv4 and IPv6 subnets in the same BYO VPC is not supported")
+	}
+	return nil
+}
+
+// validateDualStackSubnets ensures that, once any discovered BYO subnet
+// carries an IPv6CIDR, every zone's subnets do: a cluster is either
+// dual-stack everywhere or not at all, and a zone silently missing its IPv6
+// CIDR would otherwise surface as a confusing partial-IPv6 CAPA NetworkSpec.
+func validateDualStackSubnets(subnets *subnetsInput) error {
+	if subnets == nil {
+		return nil
+	}
+
+	groups := []aws.Subnets{subnets.privateSubnets, subnets.publicSubnets, subnets.edgeSubnets}
+
+	isDualStack := false
+	for _, group := range groups {
+		for _, subnet := range group {
+			if subnet.IPv6CIDR != "" {
+				isDualStack = true
+			}
+		}
+	}
+	if !isDualStack {
+		return nil
+	}
+
+	for _, group := range groups {
+		for _, subnet := range group {
+			if subnet.IPv6CIDR == "" {
+				return fmt.Errorf("zone %q subnet %s has no IPv6 CIDR, but this is a dual-stack BYO VPC", subnet.Zone.Name, subnet.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// ipv6CIDRFromInstallConfig returns the first IPv6 CIDR from the
+// install-config machine network, and true when there is one, which marks
+// the cluster as dual-stack. It does not check for an IPv4 entry.
+func ipv6CIDRFromInstallConfig(ic *installconfig.InstallConfig) (*net.IPNet, bool) {
+	if ic.Config.Networking == nil {
+		return nil, false
+	}
+
+	for _, entry := range ic.Config.Networking.MachineNetwork {
+		ip, ipnet, err := net.ParseCIDR(entry.CIDR.String())
+		if err != nil || ip.To4() != nil {
+			continue
+		}
+		return ipnet, true
+	}
+	return nil, false
+}
+
+// ipv4MachineNetworkCIDRsFromInstallConfig returns every IPv4 entry of
+// Networking.MachineNetwork, in configured order. Index 0 is always the
+// primary CIDR (the same one capiutils.CIDRFromInstallConfig returns);
+// further entries are secondary VPC CIDR blocks that a pool can opt into
+// via MachinePool.MachineNetworkIndex.
+func ipv4MachineNetworkCIDRsFromInstallConfig(ic *installconfig.InstallConfig) ([]*net.IPNet, error) {
+	if ic.Config.Networking == nil {
+		return nil, nil
+	}
+
+	var cidrs []*net.IPNet
+	for _, entry := range ic.Config.Networking.MachineNetwork {
+		ip, ipnet, err := net.ParseCIDR(entry.CIDR.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid machine network CIDR %q: %w", entry.CIDR.String(), err)
+		}
+		if ip.To4() == nil {
+			continue
+		}
+		cidrs = append(cidrs, ipnet)
+	}
+	return cidrs, nil
+}
+
+// edgeZoneMachineNetworkCIDR resolves which MachineNetwork CIDR, if any, is
+// dedicated to edge (Local Zone or Wavelength Zone) subnets: the entry
+// pinned by the edge pool's MachineNetworkIndex, or the first secondary
+// entry when one is configured and no pin is set. It returns nil when edge
+// zones should keep the long-standing default of carving a slice out of
+// the primary CIDR.
+func edgeZoneMachineNetworkCIDR(ic *installconfig.InstallConfig, cidrs []*net.IPNet) (*net.IPNet, error) {
+	if len(cidrs) < 2 {
+		return nil, nil
+	}
+
+	idx := 1
+	for _, pool := range ic.Config.Compute {
+		if pool.Name != types.MachinePoolEdgeRoleName || pool.Platform.AWS == nil || pool.Platform.AWS.MachineNetworkIndex == nil {
+			continue
+		}
+		idx = *pool.Platform.AWS.MachineNetworkIndex
+	}
+
+	if idx < 0 || idx >= len(cidrs) {
+		return nil, fmt.Errorf("machineNetworkIndex %d is out of range for %d configured MachineNetwork entries", idx, len(cidrs))
+	}
+	if idx == 0 {
+		return nil, nil
+	}
+	return cidrs[idx], nil
+}
+
+// wavelengthZoneNameFragment is the substring AWS embeds in the name (and
+// zone group) of every Wavelength Zone, e.g. "us-east-1-wl1-bos-wlz-1".
+// Local Zone names, e.g. "us-west-2-lax-1a", never contain it.
+const wavelengthZoneNameFragment = "-wl"
+
+// classifyEdgeZoneType resolves whether an edge pool zone is a Local Zone or
+// a Wavelength Zone from its name, since the two require different CAPA
+// routing (Wavelength subnets egress through a carrier gateway, Local Zone
+// ones through the standard internet gateway path).
+func classifyEdgeZoneType(zone string) string {
+	if strings.Contains(zone, wavelengthZoneNameFragment) {
+		return awstypes.WavelengthZoneType
+	}
+	return awstypes.LocalZoneType
+}
+
 // extractZonesFromInstallConfig extracts zones defined in the install-config.
 func extractZonesFromInstallConfig(in *zonesInput) (*zonesCAPI, error) {
 	out := zonesCAPI{
 		controlPlaneZones: sets.New[string](),
 		computeZones:      sets.New[string](),
-		edgeZones:         sets.New[string](),
+		localZones:        sets.New[string](),
+		wavelengthZones:   sets.New[string](),
+		outpostZones:      sets.New[string](),
+	}
+	if in.ClusterID != nil {
+		out.clusterName = in.ClusterID.InfraID
 	}
 
 	cfg := in.InstallConfig.Config
@@ -330,8 +801,29 @@ func extractZonesFromInstallConfig(in *zonesInput) (*zonesCAPI, error) {
 		defaultZones = cfg.AWS.DefaultMachinePlatform.Zones
 	}
 
+	// catalog is only built lazily, since most install-configs set Zones
+	// directly and never reference a placement expression.
+	var catalog []placement.ZoneInfo
+	zoneCatalog := func() []placement.ZoneInfo {
+		if catalog == nil {
+			catalog = buildPlacementCatalog(in)
+		}
+		return catalog
+	}
+
 	if cfg.ControlPlane != nil && cfg.ControlPlane.Platform.AWS != nil {
-		out.SetAvailabilityZones(types.MachinePoolControlPlaneRoleName, cfg.ControlPlane.Platform.AWS.Zones)
+		if expr := cfg.ControlPlane.Platform.AWS.Placement; expr != "" {
+			rule, err := placement.Parse(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid controlPlane placement expression: %w", err)
+			}
+			out.SetDefaultConfigPlacement(types.MachinePoolControlPlaneRoleName, rule, zoneCatalog())
+		} else {
+			out.SetAvailabilityZones(types.MachinePoolControlPlaneRoleName, cfg.ControlPlane.Platform.AWS.Zones)
+		}
+		if cfg.ControlPlane.Replicas != nil {
+			out.SetReplicaSpread(types.MachinePoolControlPlaneRoleName, *cfg.ControlPlane.Replicas, cfg.ControlPlane.Platform.AWS.SpreadPolicy)
+		}
 	}
 	out.SetDefaultConfigZones(types.MachinePoolControlPlaneRoleName, defaultZones, in.ZonesInRegion)
 
@@ -339,15 +831,37 @@ func extractZonesFromInstallConfig(in *zonesInput) (*zonesCAPI, error) {
 		if pool.Platform.AWS == nil {
 			continue
 		}
+
+		if expr := pool.Platform.AWS.Placement; expr != "" {
+			rule, err := placement.Parse(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid placement expression for compute pool %q: %w", pool.Name, err)
+			}
+			out.SetDefaultConfigPlacement(pool.Name, rule, zoneCatalog())
+			if pool.Replicas != nil {
+				out.SetReplicaSpread(pool.Name, *pool.Replicas, pool.Platform.AWS.SpreadPolicy)
+			}
+			continue
+		}
+
 		if len(pool.Platform.AWS.Zones) > 0 {
 			out.SetAvailabilityZones(pool.Name, pool.Platform.AWS.Zones)
 		}
 		// Ignoring as edge pool is not yet supported by CAPA.
 		// See https://github.com/openshift/installer/pull/8173
 		if pool.Name == types.MachinePoolEdgeRoleName {
-			out.edgeZones.Insert(pool.Platform.AWS.Zones...)
+			for _, zone := range pool.Platform.AWS.Zones {
+				if classifyEdgeZoneType(zone) == awstypes.WavelengthZoneType {
+					out.wavelengthZones.Insert(zone)
+					continue
+				}
+				out.localZones.Insert(zone)
+			}
 			continue
 		}
+		if pool.Replicas != nil {
+			out.SetReplicaSpread(pool.Name, *pool.Replicas, pool.Platform.AWS.SpreadPolicy)
+		}
 		out.SetDefaultConfigZones(types.MachinePoolComputeRoleName, defaultZones, in.ZonesInRegion)
 	}
 	return &out, nil
@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+
+	utilscidr "github.com/openshift/installer/pkg/asset/manifests/capiutils/cidr"
+)
+
+// setSubnetsManagedVPCByMask carves managed-VPC subnets out of the parent
+// CIDR using explicit per-tier prefix lengths from install-config.yaml,
+// instead of the default equal-split strategy. Unlike the equal-split path,
+// each tier's subnets are allocated directly out of the parent CIDR
+// (private, then public, then edge), so there is no intermediate
+// reservation of a shared block for public/edge subnets.
+func setSubnetsManagedVPCByMask(in *zonesInput, mainCIDR *net.IPNet, isPublishingExternal bool, allAvailabilityZones, allEdgeZones []string) error {
+	alloc := in.InstallConfig.Config.AWS.SubnetCIDRAllocation
+
+	tiers := []utilscidr.Tier{
+		{Name: "private", Count: len(allAvailabilityZones), PrefixLength: int(alloc.PrivateSubnetPrefix)},
+	}
+	if isPublishingExternal {
+		tiers = append(tiers, utilscidr.Tier{Name: "public", Count: len(allAvailabilityZones), PrefixLength: int(alloc.PublicSubnetPrefix)})
+	}
+	if len(allEdgeZones) > 0 {
+		edgeCount := len(allEdgeZones)
+		if isPublishingExternal {
+			edgeCount *= 2
+		}
+		tiers = append(tiers, utilscidr.Tier{Name: "edge", Count: edgeCount, PrefixLength: int(alloc.EdgeSubnetPrefix)})
+	}
+
+	blocks, err := (utilscidr.ByMaskSplitter{}).Split(mainCIDR.String(), tiers)
+	if err != nil {
+		return fmt.Errorf("unable to allocate subnet CIDRs by mask: %w", err)
+	}
+
+	privateCIDRs := blocks[0]
+	idx := 1
+	var publicCIDRs []*net.IPNet
+	if isPublishingExternal {
+		publicCIDRs = blocks[idx]
+		idx++
+	}
+	var edgeCIDRs []*net.IPNet
+	if len(allEdgeZones) > 0 {
+		edgeCIDRs = blocks[idx]
+	}
+
+	for i, zone := range allAvailabilityZones {
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			AvailabilityZone: zone,
+			CidrBlock:        privateCIDRs[i].String(),
+			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
+			IsPublic:         false,
+		})
+		if isPublishingExternal {
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				AvailabilityZone: zone,
+				CidrBlock:        publicCIDRs[i].String(),
+				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
+				IsPublic:         true,
+			})
+		}
+	}
+
+	for i, zone := range allEdgeZones {
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			AvailabilityZone: zone,
+			CidrBlock:        edgeCIDRs[i].String(),
+			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
+			IsPublic:         false,
+		})
+		if isPublishingExternal {
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				AvailabilityZone: zone,
+				CidrBlock:        edgeCIDRs[len(allEdgeZones)+i].String(),
+				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
+				IsPublic:         true,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// setSubnetsManagedVPCExplicit carves managed-VPC subnets out of
+// user-supplied, per-zone CIDR blocks instead of computing them, so that
+// adding zones later cannot renumber the CIDRs already assigned to existing
+// ones. Every requested zone must have a matching entry in the relevant
+// SubnetCIDRAllocation map, and every entry must fit inside mainCIDR.
+func setSubnetsManagedVPCExplicit(in *zonesInput, mainCIDR *net.IPNet, isPublishingExternal bool, allAvailabilityZones, allEdgeZones []string) error {
+	alloc := in.InstallConfig.Config.AWS.SubnetCIDRAllocation
+
+	for _, zone := range allAvailabilityZones {
+		cidr, err := explicitSubnetCIDR(alloc.PrivateSubnetCIDRs, zone, "private", mainCIDR)
+		if err != nil {
+			return err
+		}
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			AvailabilityZone: zone,
+			CidrBlock:        cidr,
+			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
+			IsPublic:         false,
+		})
+		if isPublishingExternal {
+			pubCIDR, err := explicitSubnetCIDR(alloc.PublicSubnetCIDRs, zone, "public", mainCIDR)
+			if err != nil {
+				return err
+			}
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				AvailabilityZone: zone,
+				CidrBlock:        pubCIDR,
+				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
+				IsPublic:         true,
+			})
+		}
+	}
+
+	for _, zone := range allEdgeZones {
+		cidr, err := explicitSubnetCIDR(alloc.EdgeSubnetCIDRs, zone, "edge-private", mainCIDR)
+		if err != nil {
+			return err
+		}
+		in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+			AvailabilityZone: zone,
+			CidrBlock:        cidr,
+			ID:               fmt.Sprintf("%s-subnet-private-%s", in.ClusterID.InfraID, zone),
+			IsPublic:         false,
+		})
+		if isPublishingExternal {
+			pubCIDR, err := explicitSubnetCIDR(alloc.EdgePublicSubnetCIDRs, zone, "edge-public", mainCIDR)
+			if err != nil {
+				return err
+			}
+			in.Cluster.Spec.NetworkSpec.Subnets = append(in.Cluster.Spec.NetworkSpec.Subnets, capa.SubnetSpec{
+				AvailabilityZone: zone,
+				CidrBlock:        pubCIDR,
+				ID:               fmt.Sprintf("%s-subnet-public-%s", in.ClusterID.InfraID, zone),
+				IsPublic:         true,
+			})
+		}
+	}
+
+	return validateNoExplicitSubnetOverlap(in.Cluster.Spec.NetworkSpec.Subnets)
+}
+
+// explicitSubnetCIDR looks up the CIDR assigned to zone in cidrsByZone for
+// the given subnet role, and validates that it fits inside parentCIDR.
+func explicitSubnetCIDR(cidrsByZone map[string]string, zone, role string, parentCIDR *net.IPNet) (string, error) {
+	cidrStr, ok := cidrsByZone[zone]
+	if !ok {
+		return "", fmt.Errorf("no explicit %s subnet CIDR configured for zone %q", role, zone)
+	}
+
+	ip, ipnet, err := net.ParseCIDR(cidrStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s subnet CIDR %q for zone %q: %w", role, cidrStr, zone, err)
+	}
+	if !parentCIDR.Contains(ip) {
+		return "", fmt.Errorf("%s subnet CIDR %q for zone %q does not fit inside machine network %q", role, cidrStr, zone, parentCIDR.String())
+	}
+	parentOnes, _ := parentCIDR.Mask.Size()
+	subnetOnes, _ := ipnet.Mask.Size()
+	if subnetOnes < parentOnes {
+		return "", fmt.Errorf("%s subnet CIDR %q for zone %q is wider than the machine network %q", role, cidrStr, zone, parentCIDR.String())
+	}
+
+	return ipnet.String(), nil
+}
+
+// validateNoExplicitSubnetOverlap fails fast when two explicitly configured
+// subnets overlap, which the bin-packed strategies can never produce but a
+// hand-written explicit CIDR list can.
+func validateNoExplicitSubnetOverlap(subnets []capa.SubnetSpec) error {
+	for i := range subnets {
+		_, a, err := net.ParseCIDR(subnets[i].CidrBlock)
+		if err != nil {
+			continue
+		}
+		for j := i + 1; j < len(subnets); j++ {
+			_, b, err := net.ParseCIDR(subnets[j].CidrBlock)
+			if err != nil {
+				continue
+			}
+			if a.Contains(b.IP) || b.Contains(a.IP) {
+				return fmt.Errorf("explicit subnet CIDRs %q (%s) and %q (%s) overlap", subnets[i].ID, subnets[i].CidrBlock, subnets[j].ID, subnets[j].CidrBlock)
+			}
+		}
+	}
+	return nil
+}
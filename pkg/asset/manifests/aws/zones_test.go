@@ -78,6 +78,19 @@ func stubInstallConfigPoolComputeWithEdge() []types.MachinePool {
 	return p
 }
 
+func stubInstallConfigPoolComputeWithMixedEdge() []types.MachinePool {
+	p := stubInstallCOnfigPoolCompute()
+	p = append(p, types.MachinePool{
+		Name: "edge",
+		Platform: types.MachinePoolPlatform{
+			AWS: &awstypes.MachinePool{
+				Zones: []string{"edge-b", "us-east-1-wl1-bos-wlz-1"},
+			},
+		},
+	})
+	return p
+}
+
 func stubInstallConfigPoolControl() *types.MachinePool {
 	return &types.MachinePool{
 		Name: "master",
@@ -125,119 +138,738 @@ func Test_extractZonesFromInstallConfig(t *testing.T) {
 					}(),
 				},
 			},
-			want: &zonesCAPI{
-				controlPlaneZones: sets.Set[string]{},
-				computeZones:      sets.Set[string]{},
-				edgeZones:         sets.Set[string]{},
-			},
+			want: &zonesCAPI{
+				controlPlaneZones: sets.Set[string]{},
+				computeZones:      sets.Set[string]{},
+				localZones:        sets.Set[string]{},
+				wavelengthZones:   sets.Set[string]{},
+				outpostZones:      sets.Set[string]{},
+			},
+		},
+		{
+			name: "custom zones control plane pool",
+			args: args{
+				in: &zonesInput{
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							ControlPlane: stubInstallConfigPoolControl(),
+							Compute:      nil,
+						}
+						return ic
+					}(),
+				},
+			},
+			want: &zonesCAPI{
+				controlPlaneZones: sets.New("a", "b"),
+				computeZones:      sets.Set[string]{},
+				localZones:        sets.Set[string]{},
+				wavelengthZones:   sets.Set[string]{},
+				outpostZones:      sets.Set[string]{},
+			},
+		},
+		{
+			name: "custom zones compute pool",
+			args: args{
+				in: &zonesInput{
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							ControlPlane: nil,
+							Compute:      stubInstallCOnfigPoolCompute(),
+						}
+						return ic
+					}(),
+				},
+			},
+			want: &zonesCAPI{
+				controlPlaneZones: sets.Set[string]{},
+				computeZones:      sets.New("b", "c"),
+				localZones:        sets.Set[string]{},
+				wavelengthZones:   sets.Set[string]{},
+				outpostZones:      sets.Set[string]{},
+			},
+		},
+		{
+			name: "custom zones control plane and compute pools",
+			args: args{
+				in: &zonesInput{
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							ControlPlane: stubInstallConfigPoolControl(),
+							Compute:      stubInstallCOnfigPoolCompute(),
+						}
+						return ic
+					}(),
+				},
+			},
+			want: &zonesCAPI{
+				controlPlaneZones: sets.New("a", "b"),
+				computeZones:      sets.New("b", "c"),
+				localZones:        sets.Set[string]{},
+				wavelengthZones:   sets.Set[string]{},
+				outpostZones:      sets.Set[string]{},
+			},
+		},
+		{
+			name: "custom zones control plane, compute and edge pools",
+			args: args{
+				in: &zonesInput{
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							ControlPlane: stubInstallConfigPoolControl(),
+							Compute:      stubInstallConfigPoolComputeWithEdge(),
+						}
+						return ic
+					}(),
+				},
+			},
+			want: &zonesCAPI{
+				controlPlaneZones: sets.New("a", "b"),
+				computeZones:      sets.New("b", "c"),
+				localZones:        sets.New("edge-b", "edge-c"),
+				wavelengthZones:   sets.Set[string]{},
+				outpostZones:      sets.Set[string]{},
+			},
+		},
+		{
+			name: "edge pool with a mix of local and wavelength zones",
+			args: args{
+				in: &zonesInput{
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							ControlPlane: stubInstallConfigPoolControl(),
+							Compute:      stubInstallConfigPoolComputeWithMixedEdge(),
+						}
+						return ic
+					}(),
+				},
+			},
+			want: &zonesCAPI{
+				controlPlaneZones: sets.New("a", "b"),
+				computeZones:      sets.New("b", "c"),
+				localZones:        sets.New("edge-b"),
+				wavelengthZones:   sets.New("us-east-1-wl1-bos-wlz-1"),
+				outpostZones:      sets.Set[string]{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractZonesFromInstallConfig(tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("extractZonesFromInstallConfig() error: %v, wantErr: %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractZonesFromInstallConfig() err=%v\ngot : %#v,\nwant: %#v\n", err, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setSubnetsManagedVPC(t *testing.T) {
+	type args struct {
+		in *zonesInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+		want    *capa.NetworkSpec
+	}{
+		{
+			name: "regular zones in the region",
+			args: args{
+				in: &zonesInput{
+					ClusterID: stubClusterID(),
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+						}
+						return ic
+					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b", "c"},
+				},
+			},
+			want: &capa.NetworkSpec{
+				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				Subnets: []capa.SubnetSpec{
+					{
+						ID:               "infra-id-subnet-private-a",
+						AvailabilityZone: "a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.0.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-b",
+						AvailabilityZone: "b",
+						IsPublic:         false,
+						CidrBlock:        "10.0.32.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-c",
+						AvailabilityZone: "c",
+						IsPublic:         false,
+						CidrBlock:        "10.0.64.0/19",
+					}, {
+						ID:               "infra-id-subnet-public-a",
+						AvailabilityZone: "a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.96.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-b",
+						AvailabilityZone: "b",
+						IsPublic:         true,
+						CidrBlock:        "10.0.104.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-c",
+						AvailabilityZone: "c",
+						IsPublic:         true,
+						CidrBlock:        "10.0.112.0/21",
+					},
+				},
+			},
+		},
+		{
+			name: "regular zones in the region with edge",
+			args: args{
+				in: &zonesInput{
+					ClusterID: stubClusterID(),
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+							Compute: []types.MachinePool{
+								{
+									Name: "edge",
+									Platform: types.MachinePoolPlatform{
+										AWS: &awstypes.MachinePool{
+											Zones: []string{"edge-a"},
+										},
+									},
+								},
+							},
+						}
+						return ic
+					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b", "c"},
+				},
+			},
+			want: &capa.NetworkSpec{
+				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				Subnets: []capa.SubnetSpec{
+					{
+						ID:               "infra-id-subnet-private-a",
+						AvailabilityZone: "a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.0.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-b",
+						AvailabilityZone: "b",
+						IsPublic:         false,
+						CidrBlock:        "10.0.32.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-c",
+						AvailabilityZone: "c",
+						IsPublic:         false,
+						CidrBlock:        "10.0.64.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-edge-a",
+						AvailabilityZone: "edge-a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.128.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-a",
+						AvailabilityZone: "a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.96.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-b",
+						AvailabilityZone: "b",
+						IsPublic:         true,
+						CidrBlock:        "10.0.104.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-c",
+						AvailabilityZone: "c",
+						IsPublic:         true,
+						CidrBlock:        "10.0.112.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-edge-a",
+						AvailabilityZone: "edge-a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.136.0/21",
+					},
+				},
+			},
+		},
+		{
+			name: "regular zones in the region with a wavelength edge zone",
+			args: args{
+				in: &zonesInput{
+					ClusterID: stubClusterID(),
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+							Compute: []types.MachinePool{
+								{
+									Name: "edge",
+									Platform: types.MachinePoolPlatform{
+										AWS: &awstypes.MachinePool{
+											Zones: []string{"us-east-1-wl1-bos-wlz-1"},
+										},
+									},
+								},
+							},
+						}
+						return ic
+					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b", "c"},
+				},
+			},
+			want: &capa.NetworkSpec{
+				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				Subnets: []capa.SubnetSpec{
+					{
+						ID:               "infra-id-subnet-private-a",
+						AvailabilityZone: "a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.0.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-b",
+						AvailabilityZone: "b",
+						IsPublic:         false,
+						CidrBlock:        "10.0.32.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-c",
+						AvailabilityZone: "c",
+						IsPublic:         false,
+						CidrBlock:        "10.0.64.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-us-east-1-wl1-bos-wlz-1",
+						AvailabilityZone: "us-east-1-wl1-bos-wlz-1",
+						IsPublic:         false,
+						CidrBlock:        "10.0.128.0/21",
+						IsWavelengthZone: true,
+					}, {
+						ID:               "infra-id-subnet-public-a",
+						AvailabilityZone: "a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.96.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-b",
+						AvailabilityZone: "b",
+						IsPublic:         true,
+						CidrBlock:        "10.0.104.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-c",
+						AvailabilityZone: "c",
+						IsPublic:         true,
+						CidrBlock:        "10.0.112.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-us-east-1-wl1-bos-wlz-1",
+						AvailabilityZone: "us-east-1-wl1-bos-wlz-1",
+						IsPublic:         true,
+						CidrBlock:        "10.0.136.0/21",
+						IsWavelengthZone: true,
+					},
+				},
+			},
+		},
+		{
+			name: "custom ByMask prefixes",
+			args: args{
+				in: &zonesInput{
+					ClusterID: stubClusterID(),
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+							AWS: &awstypes.Platform{
+								SubnetCIDRAllocation: &awstypes.SubnetCIDRAllocation{
+									Strategy:            awstypes.SubnetCIDRAllocationStrategyByMask,
+									PrivateSubnetPrefix: 24,
+									PublicSubnetPrefix:  27,
+								},
+							},
+						}
+						return ic
+					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b"},
+				},
+			},
+			want: &capa.NetworkSpec{
+				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				Subnets: []capa.SubnetSpec{
+					{
+						ID:               "infra-id-subnet-private-a",
+						AvailabilityZone: "a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.0.0/24",
+					}, {
+						ID:               "infra-id-subnet-private-b",
+						AvailabilityZone: "b",
+						IsPublic:         false,
+						CidrBlock:        "10.0.1.0/24",
+					}, {
+						ID:               "infra-id-subnet-public-a",
+						AvailabilityZone: "a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.2.0/27",
+					}, {
+						ID:               "infra-id-subnet-public-b",
+						AvailabilityZone: "b",
+						IsPublic:         true,
+						CidrBlock:        "10.0.2.32/27",
+					},
+				},
+			},
+		},
+		{
+			name:    "ByMask prefix wider than machine network fails",
+			wantErr: true,
+			args: args{
+				in: &zonesInput{
+					ClusterID: stubClusterID(),
+					InstallConfig: func() *installconfig.InstallConfig {
+						ic := stubInstallConfig()
+						ic.Config = &types.InstallConfig{
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+							AWS: &awstypes.Platform{
+								SubnetCIDRAllocation: &awstypes.SubnetCIDRAllocation{
+									Strategy:            awstypes.SubnetCIDRAllocationStrategyByMask,
+									PrivateSubnetPrefix: 15,
+									PublicSubnetPrefix:  27,
+								},
+							},
+						}
+						return ic
+					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b"},
+				},
+			},
 		},
 		{
-			name: "custom zones control plane pool",
+			name: "explicit CIDR overrides",
 			args: args{
 				in: &zonesInput{
+					ClusterID: stubClusterID(),
 					InstallConfig: func() *installconfig.InstallConfig {
 						ic := stubInstallConfig()
 						ic.Config = &types.InstallConfig{
-							ControlPlane: stubInstallConfigPoolControl(),
-							Compute:      nil,
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+							AWS: &awstypes.Platform{
+								SubnetCIDRAllocation: &awstypes.SubnetCIDRAllocation{
+									Strategy: awstypes.SubnetCIDRAllocationStrategyExplicit,
+									PrivateSubnetCIDRs: map[string]string{
+										"a": "10.0.0.0/24",
+										"b": "10.0.1.0/24",
+									},
+									PublicSubnetCIDRs: map[string]string{
+										"a": "10.0.2.0/27",
+										"b": "10.0.2.32/27",
+									},
+								},
+							},
 						}
 						return ic
 					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b"},
 				},
 			},
-			want: &zonesCAPI{
-				controlPlaneZones: sets.New("a", "b"),
-				computeZones:      sets.Set[string]{},
-				edgeZones:         sets.Set[string]{},
+			want: &capa.NetworkSpec{
+				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				Subnets: []capa.SubnetSpec{
+					{
+						ID:               "infra-id-subnet-private-a",
+						AvailabilityZone: "a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.0.0/24",
+					}, {
+						ID:               "infra-id-subnet-private-b",
+						AvailabilityZone: "b",
+						IsPublic:         false,
+						CidrBlock:        "10.0.1.0/24",
+					}, {
+						ID:               "infra-id-subnet-public-a",
+						AvailabilityZone: "a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.2.0/27",
+					}, {
+						ID:               "infra-id-subnet-public-b",
+						AvailabilityZone: "b",
+						IsPublic:         true,
+						CidrBlock:        "10.0.2.32/27",
+					},
+				},
 			},
 		},
 		{
-			name: "custom zones compute pool",
+			name:    "explicit CIDR missing zone fails",
+			wantErr: true,
 			args: args{
 				in: &zonesInput{
+					ClusterID: stubClusterID(),
 					InstallConfig: func() *installconfig.InstallConfig {
 						ic := stubInstallConfig()
 						ic.Config = &types.InstallConfig{
-							ControlPlane: nil,
-							Compute:      stubInstallCOnfigPoolCompute(),
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+								},
+							},
+							AWS: &awstypes.Platform{
+								SubnetCIDRAllocation: &awstypes.SubnetCIDRAllocation{
+									Strategy: awstypes.SubnetCIDRAllocationStrategyExplicit,
+									PrivateSubnetCIDRs: map[string]string{
+										"a": "10.0.0.0/24",
+									},
+								},
+							},
 						}
 						return ic
 					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b"},
 				},
 			},
-			want: &zonesCAPI{
-				controlPlaneZones: sets.Set[string]{},
-				computeZones:      sets.New("b", "c"),
-				edgeZones:         sets.Set[string]{},
-			},
 		},
 		{
-			name: "custom zones control plane and compute pools",
+			name: "secondary MachineNetwork CIDR dedicated to edge zones",
 			args: args{
 				in: &zonesInput{
+					ClusterID: stubClusterID(),
 					InstallConfig: func() *installconfig.InstallConfig {
 						ic := stubInstallConfig()
 						ic.Config = &types.InstallConfig{
-							ControlPlane: stubInstallConfigPoolControl(),
-							Compute:      stubInstallCOnfigPoolCompute(),
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+									{
+										CIDR: *ipnet.MustParseCIDR("10.1.0.0/20"),
+									},
+								},
+							},
+							Compute: []types.MachinePool{
+								{
+									Name: "edge",
+									Platform: types.MachinePoolPlatform{
+										AWS: &awstypes.MachinePool{
+											Zones: []string{"edge-a"},
+										},
+									},
+								},
+							},
 						}
 						return ic
 					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b", "c"},
 				},
 			},
-			want: &zonesCAPI{
-				controlPlaneZones: sets.New("a", "b"),
-				computeZones:      sets.New("b", "c"),
-				edgeZones:         sets.Set[string]{},
+			want: &capa.NetworkSpec{
+				VPC: capa.VPCSpec{
+					CidrBlock:           stubDefaultCIDR,
+					SecondaryCidrBlocks: []capa.VpcCidrBlock{{IPv4CidrBlock: "10.1.0.0/20"}},
+				},
+				Subnets: []capa.SubnetSpec{
+					{
+						ID:               "infra-id-subnet-private-a",
+						AvailabilityZone: "a",
+						IsPublic:         false,
+						CidrBlock:        "10.0.0.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-b",
+						AvailabilityZone: "b",
+						IsPublic:         false,
+						CidrBlock:        "10.0.32.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-c",
+						AvailabilityZone: "c",
+						IsPublic:         false,
+						CidrBlock:        "10.0.64.0/19",
+					}, {
+						ID:               "infra-id-subnet-private-edge-a",
+						AvailabilityZone: "edge-a",
+						IsPublic:         false,
+						CidrBlock:        "10.1.0.0/22",
+					}, {
+						ID:               "infra-id-subnet-public-a",
+						AvailabilityZone: "a",
+						IsPublic:         true,
+						CidrBlock:        "10.0.96.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-b",
+						AvailabilityZone: "b",
+						IsPublic:         true,
+						CidrBlock:        "10.0.104.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-c",
+						AvailabilityZone: "c",
+						IsPublic:         true,
+						CidrBlock:        "10.0.112.0/21",
+					}, {
+						ID:               "infra-id-subnet-public-edge-a",
+						AvailabilityZone: "edge-a",
+						IsPublic:         true,
+						CidrBlock:        "10.1.4.0/22",
+					},
+				},
 			},
 		},
 		{
-			name: "custom zones control plane, compute and edge pools",
+			name:    "machineNetworkIndex out of range fails",
+			wantErr: true,
 			args: args{
 				in: &zonesInput{
+					ClusterID: stubClusterID(),
 					InstallConfig: func() *installconfig.InstallConfig {
 						ic := stubInstallConfig()
+						pinnedIndex := 2
 						ic.Config = &types.InstallConfig{
-							ControlPlane: stubInstallConfigPoolControl(),
-							Compute:      stubInstallConfigPoolComputeWithEdge(),
+							Publish: types.ExternalPublishingStrategy,
+							Networking: &types.Networking{
+								MachineNetwork: []types.MachineNetworkEntry{
+									{
+										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
+									},
+									{
+										CIDR: *ipnet.MustParseCIDR("10.1.0.0/20"),
+									},
+								},
+							},
+							Compute: []types.MachinePool{
+								{
+									Name: "edge",
+									Platform: types.MachinePoolPlatform{
+										AWS: &awstypes.MachinePool{
+											Zones:               []string{"edge-a"},
+											MachineNetworkIndex: &pinnedIndex,
+										},
+									},
+								},
+							},
 						}
 						return ic
 					}(),
+					Cluster: &capa.AWSCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "infraId",
+							Namespace: capiutils.Namespace,
+						},
+						Spec: capa.AWSClusterSpec{},
+					},
+					ZonesInRegion: []string{"a", "b", "c"},
 				},
 			},
-			want: &zonesCAPI{
-				controlPlaneZones: sets.New("a", "b"),
-				computeZones:      sets.New("b", "c"),
-				edgeZones:         sets.New("edge-b", "edge-c"),
-			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := extractZonesFromInstallConfig(tt.args.in)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("extractZonesFromInstallConfig() error: %v, wantErr: %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("extractZonesFromInstallConfig() err=%v\ngot : %#v,\nwant: %#v\n", err, got, tt.want)
-			}
-		})
-	}
-}
-
-func Test_setSubnetsManagedVPC(t *testing.T) {
-	type args struct {
-		in *zonesInput
-	}
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-		want    *capa.NetworkSpec
-	}{
 		{
-			name: "regular zones in the region",
+			name: "dual-stack managed VPC assigns IPv6 CIDRs per zone",
 			args: args{
 				in: &zonesInput{
 					ClusterID: stubClusterID(),
@@ -250,6 +882,9 @@ func Test_setSubnetsManagedVPC(t *testing.T) {
 									{
 										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
 									},
+									{
+										CIDR: *ipnet.MustParseCIDR("fd00:1234:5678::/56"),
+									},
 								},
 							},
 						}
@@ -266,44 +901,59 @@ func Test_setSubnetsManagedVPC(t *testing.T) {
 				},
 			},
 			want: &capa.NetworkSpec{
-				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				VPC: capa.VPCSpec{
+					CidrBlock: stubDefaultCIDR,
+					IPv6:      &capa.IPv6{CidrBlock: "fd00:1234:5678::/56"},
+				},
 				Subnets: []capa.SubnetSpec{
 					{
 						ID:               "infra-id-subnet-private-a",
 						AvailabilityZone: "a",
 						IsPublic:         false,
 						CidrBlock:        "10.0.0.0/19",
+						IPv6CidrBlock:    "fd00:1234:5678::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-private-b",
 						AvailabilityZone: "b",
 						IsPublic:         false,
 						CidrBlock:        "10.0.32.0/19",
+						IPv6CidrBlock:    "fd00:1234:5678:1::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-private-c",
 						AvailabilityZone: "c",
 						IsPublic:         false,
 						CidrBlock:        "10.0.64.0/19",
+						IPv6CidrBlock:    "fd00:1234:5678:2::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-a",
 						AvailabilityZone: "a",
 						IsPublic:         true,
 						CidrBlock:        "10.0.96.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:3::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-b",
 						AvailabilityZone: "b",
 						IsPublic:         true,
 						CidrBlock:        "10.0.104.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:4::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-c",
 						AvailabilityZone: "c",
 						IsPublic:         true,
 						CidrBlock:        "10.0.112.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:5::/64",
+						IsIPv6:           true,
 					},
 				},
 			},
 		},
 		{
-			name: "regular zones in the region with edge",
+			name: "dual-stack managed VPC with edge zones orders private then public then edge",
 			args: args{
 				in: &zonesInput{
 					ClusterID: stubClusterID(),
@@ -316,6 +966,9 @@ func Test_setSubnetsManagedVPC(t *testing.T) {
 									{
 										CIDR: *ipnet.MustParseCIDR(stubDefaultCIDR),
 									},
+									{
+										CIDR: *ipnet.MustParseCIDR("fd00:1234:5678::/56"),
+									},
 								},
 							},
 							Compute: []types.MachinePool{
@@ -342,48 +995,67 @@ func Test_setSubnetsManagedVPC(t *testing.T) {
 				},
 			},
 			want: &capa.NetworkSpec{
-				VPC: capa.VPCSpec{CidrBlock: stubDefaultCIDR},
+				VPC: capa.VPCSpec{
+					CidrBlock: stubDefaultCIDR,
+					IPv6:      &capa.IPv6{CidrBlock: "fd00:1234:5678::/56"},
+				},
 				Subnets: []capa.SubnetSpec{
 					{
 						ID:               "infra-id-subnet-private-a",
 						AvailabilityZone: "a",
 						IsPublic:         false,
 						CidrBlock:        "10.0.0.0/19",
+						IPv6CidrBlock:    "fd00:1234:5678::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-private-b",
 						AvailabilityZone: "b",
 						IsPublic:         false,
 						CidrBlock:        "10.0.32.0/19",
+						IPv6CidrBlock:    "fd00:1234:5678:1::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-private-c",
 						AvailabilityZone: "c",
 						IsPublic:         false,
 						CidrBlock:        "10.0.64.0/19",
+						IPv6CidrBlock:    "fd00:1234:5678:2::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-private-edge-a",
 						AvailabilityZone: "edge-a",
 						IsPublic:         false,
 						CidrBlock:        "10.0.128.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:6::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-a",
 						AvailabilityZone: "a",
 						IsPublic:         true,
 						CidrBlock:        "10.0.96.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:3::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-b",
 						AvailabilityZone: "b",
 						IsPublic:         true,
 						CidrBlock:        "10.0.104.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:4::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-c",
 						AvailabilityZone: "c",
 						IsPublic:         true,
 						CidrBlock:        "10.0.112.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:5::/64",
+						IsIPv6:           true,
 					}, {
 						ID:               "infra-id-subnet-public-edge-a",
 						AvailabilityZone: "edge-a",
 						IsPublic:         true,
 						CidrBlock:        "10.0.136.0/21",
+						IPv6CidrBlock:    "fd00:1234:5678:7::/64",
+						IsIPv6:           true,
 					},
 				},
 			},
@@ -1013,7 +1685,7 @@ func Test_zonesCAPI_AvailabilityZones(t *testing.T) {
 		{
 			name: "empty az",
 			zones: &zonesCAPI{
-				edgeZones: sets.New("edge-x", "edge-y"),
+				localZones: sets.New("edge-x", "edge-y"),
 			},
 			want: []string{},
 		},
@@ -1022,7 +1694,7 @@ func Test_zonesCAPI_AvailabilityZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.New("a", "b"),
 				computeZones:      sets.New("b", "c"),
-				edgeZones:         sets.New("edge-x", "edge-y"),
+				localZones:        sets.New("edge-x", "edge-y"),
 			},
 			want: []string{"a", "b", "c"},
 		},
@@ -1031,7 +1703,7 @@ func Test_zonesCAPI_AvailabilityZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.New("x", "a"),
 				computeZones:      sets.New("b", "a"),
-				edgeZones:         sets.New("edge-x", "edge-y"),
+				localZones:        sets.New("edge-x", "edge-y"),
 			},
 			want: []string{"a", "b", "x"},
 		},
@@ -1040,7 +1712,7 @@ func Test_zonesCAPI_AvailabilityZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.New("x", "a"),
 				computeZones:      sets.Set[string]{},
-				edgeZones:         sets.New("edge-x", "edge-y"),
+				localZones:        sets.New("edge-x", "edge-y"),
 			},
 			want: []string{"a", "x"},
 		},
@@ -1049,7 +1721,7 @@ func Test_zonesCAPI_AvailabilityZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.Set[string]{},
 				computeZones:      sets.New("x", "a"),
-				edgeZones:         sets.New("edge-x", "edge-y"),
+				localZones:        sets.New("edge-x", "edge-y"),
 			},
 			want: []string{"a", "x"},
 		},
@@ -1085,7 +1757,7 @@ func Test_zonesCAPI_EdgeZones(t *testing.T) {
 		{
 			name: "empty only",
 			zones: &zonesCAPI{
-				edgeZones: sets.New("edge-x"),
+				localZones: sets.New("edge-x"),
 			},
 			want: []string{"edge-x"},
 		},
@@ -1094,7 +1766,7 @@ func Test_zonesCAPI_EdgeZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.New("a", "b"),
 				computeZones:      sets.New("b", "c"),
-				edgeZones:         sets.New("edge-x", "edge-y"),
+				localZones:        sets.New("edge-x", "edge-y"),
 			},
 			want: []string{"edge-x", "edge-y"},
 		},
@@ -1103,7 +1775,7 @@ func Test_zonesCAPI_EdgeZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.New("x", "a"),
 				computeZones:      sets.New("b", "a"),
-				edgeZones:         sets.New("edge-y", "edge-a"),
+				localZones:        sets.New("edge-y", "edge-a"),
 			},
 			want: []string{"edge-a", "edge-y"},
 		},
@@ -1112,7 +1784,7 @@ func Test_zonesCAPI_EdgeZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.New("x", "a"),
 				computeZones:      sets.Set[string]{},
-				edgeZones:         sets.New("edge-a", "edge-y"),
+				localZones:        sets.New("edge-a", "edge-y"),
 			},
 			want: []string{"edge-a", "edge-y"},
 		},
@@ -1121,7 +1793,7 @@ func Test_zonesCAPI_EdgeZones(t *testing.T) {
 			zones: &zonesCAPI{
 				controlPlaneZones: sets.Set[string]{},
 				computeZones:      sets.New("x", "a"),
-				edgeZones:         sets.New("edge-a", "edge-y"),
+				localZones:        sets.New("edge-a", "edge-y"),
 			},
 			want: []string{"edge-a", "edge-y"},
 		},
@@ -1135,3 +1807,38 @@ func Test_zonesCAPI_EdgeZones(t *testing.T) {
 		})
 	}
 }
+
+func Test_extractZonesFromInstallConfig_placementPoolSetsReplicaSpread(t *testing.T) {
+	replicas := int64(3)
+	ic := stubInstallConfigType()
+	ic.AWS = &awstypes.Platform{Region: "us-east-1"}
+	ic.ControlPlane = stubInstallConfigPoolControl()
+	ic.Compute = []types.MachinePool{
+		{
+			Name:     "worker",
+			Replicas: &replicas,
+			Platform: types.MachinePoolPlatform{
+				AWS: &awstypes.MachinePool{
+					Placement:    "region(us-east-1)",
+					SpreadPolicy: awstypes.SpreadMaxAZ,
+				},
+			},
+		},
+	}
+
+	in := &zonesInput{
+		ClusterID:     stubClusterID(),
+		InstallConfig: &installconfig.InstallConfig{Config: ic},
+		ZonesInRegion: []string{"us-east-1a", "us-east-1b"},
+	}
+
+	out, err := extractZonesFromInstallConfig(in)
+	assert.NoError(t, err)
+
+	// A compute pool routed through a placement expression must still get
+	// its replicas recorded via SetReplicaSpread, same as one using an
+	// explicit Zones list; skipping it would silently drop every replica
+	// for the pool once DistributeReplicas is consulted downstream.
+	assert.Equal(t, replicas, out.replicas["worker"])
+	assert.Equal(t, awstypes.SpreadMaxAZ, out.spreadPolicies["worker"])
+}
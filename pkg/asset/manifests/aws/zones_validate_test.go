@@ -0,0 +1,119 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func stubBYOSubnetsInZones(zones ...string) *subnetsInput {
+	subnets := icaws.Subnets{}
+	for _, zone := range zones {
+		subnets["subnetId-"+zone] = icaws.Subnet{
+			ID:   "subnetId-" + zone,
+			CIDR: "10.0.1.0/24",
+			Zone: &icaws.Zone{Name: zone},
+		}
+	}
+	return &subnetsInput{privateSubnets: subnets}
+}
+
+func TestValidateDualStackSubnets(t *testing.T) {
+	cases := []struct {
+		name      string
+		subnets   *subnetsInput
+		expectErr string
+	}{
+		{
+			name:    "single-stack BYO VPC",
+			subnets: stubBYOSubnetsInZones("a", "b"),
+		},
+		{
+			name: "dual-stack BYO VPC with every zone carrying an IPv6 CIDR",
+			subnets: &subnetsInput{privateSubnets: icaws.Subnets{
+				"subnetId-a": icaws.Subnet{ID: "subnetId-a", CIDR: "10.0.1.0/24", IPv6CIDR: "fd00::/64", Zone: &icaws.Zone{Name: "a"}},
+				"subnetId-b": icaws.Subnet{ID: "subnetId-b", CIDR: "10.0.2.0/24", IPv6CIDR: "fd00:0:0:1::/64", Zone: &icaws.Zone{Name: "b"}},
+			}},
+		},
+		{
+			name: "dual-stack BYO VPC missing an IPv6 CIDR on one zone",
+			subnets: &subnetsInput{privateSubnets: icaws.Subnets{
+				"subnetId-a": icaws.Subnet{ID: "subnetId-a", CIDR: "10.0.1.0/24", IPv6CIDR: "fd00::/64", Zone: &icaws.Zone{Name: "a"}},
+				"subnetId-b": icaws.Subnet{ID: "subnetId-b", CIDR: "10.0.2.0/24", Zone: &icaws.Zone{Name: "b"}},
+			}},
+			expectErr: "has no IPv6 CIDR",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDualStackSubnets(tc.subnets)
+			if tc.expectErr != "" {
+				assert.Error(t, err)
+				assert.Regexp(t, tc.expectErr, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateZonesInput(t *testing.T) {
+	cases := []struct {
+		name      string
+		subnets   *subnetsInput
+		cfg       *types.InstallConfig
+		expectErr string
+	}{
+		{
+			name:    "control plane zones match byo subnets",
+			subnets: stubBYOSubnetsInZones("a", "b", "c"),
+			cfg: &types.InstallConfig{
+				ControlPlane: &types.MachinePool{
+					Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{Zones: []string{"a", "b"}}},
+				},
+			},
+		},
+		{
+			name:    "control plane zones exceed byo subnets",
+			subnets: stubBYOSubnetsInZones("a", "b"),
+			cfg: &types.InstallConfig{
+				ControlPlane: &types.MachinePool{
+					Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{Zones: []string{"a", "b", "c"}}},
+				},
+			},
+			expectErr: "controlPlane zones .* are not a subset",
+		},
+		{
+			name:    "compute pool zones exceed byo subnets",
+			subnets: stubBYOSubnetsInZones("a"),
+			cfg: &types.InstallConfig{
+				Compute: []types.MachinePool{
+					{Name: "worker", Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{Zones: []string{"a", "b"}}}},
+				},
+			},
+			expectErr: `compute pool "worker" zones .* are not a subset`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := &zonesInput{
+				InstallConfig: &installconfig.InstallConfig{Config: tc.cfg},
+				Subnets:       tc.subnets,
+			}
+			err := validateZonesInput(in)
+			if tc.expectErr != "" {
+				assert.Error(t, err)
+				assert.Regexp(t, tc.expectErr, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
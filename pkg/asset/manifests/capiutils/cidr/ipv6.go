@@ -0,0 +1,58 @@
+package cidr
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// ipv6SubnetPrefix is the prefix length used for each per-zone IPv6 subnet
+// carved out of the parent VPC IPv6 block. AWS always allocates /56 VPC
+// IPv6 blocks (Amazon-provided or BYO), and requires /64 subnets.
+const ipv6SubnetPrefix = 64
+
+// SplitIntoSubnetsIPv6 divides the given IPv6 parent CIDR (typically a /56,
+// as allocated by AWS for a VPC) into exactly count non-overlapping /64
+// blocks, or returns an error when the parent holds fewer. Blocks are returned in ascending address order, so the result is
+// deterministic and stable across calls with the same parent and count,
+// allowing Day-2 zone expansions to keep reusing the same allocation.
+func SplitIntoSubnetsIPv6(parentCIDR string, count int) ([]*net.IPNet, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be greater than zero")
+	}
+
+	_, parent, err := net.ParseCIDR(parentCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse IPv6 parent CIDR %q: %w", parentCIDR, err)
+	}
+	if parent.IP.To4() != nil {
+		return nil, fmt.Errorf("%q is not an IPv6 CIDR", parentCIDR)
+	}
+
+	parentOnes, bits := parent.Mask.Size()
+	if parentOnes > ipv6SubnetPrefix {
+		return nil, fmt.Errorf("parent CIDR %q is already narrower than /%d", parentCIDR, ipv6SubnetPrefix)
+	}
+
+	available := 1 << uint(ipv6SubnetPrefix-parentOnes)
+	if count > available {
+		return nil, fmt.Errorf("cannot carve %d /%d blocks out of %q: only %d available", count, ipv6SubnetPrefix, parentCIDR, available)
+	}
+
+	subnets := make([]*net.IPNet, 0, count)
+	base := new(big.Int).SetBytes(parent.IP.To16())
+	blockSize := new(big.Int).Lsh(big.NewInt(1), uint(bits-ipv6SubnetPrefix))
+	for i := 0; i < count; i++ {
+		offset := new(big.Int).Mul(blockSize, big.NewInt(int64(i)))
+		addr := new(big.Int).Add(base, offset)
+
+		ip := make(net.IP, net.IPv6len)
+		addr.FillBytes(ip)
+		subnets = append(subnets, &net.IPNet{
+			IP:   ip,
+			Mask: net.CIDRMask(ipv6SubnetPrefix, bits),
+		})
+	}
+
+	return subnets, nil
+}
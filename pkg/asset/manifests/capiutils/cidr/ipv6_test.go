@@ -0,0 +1,62 @@
+package cidr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitIntoSubnetsIPv6(t *testing.T) {
+	cases := []struct {
+		name      string
+		parent    string
+		count     int
+		expect    []string
+		expectErr string
+	}{
+		{
+			name:   "single zone",
+			parent: "2600:1f14:e08:7f00::/56",
+			count:  1,
+			expect: []string{"2600:1f14:e08:7f00::/64"},
+		},
+		{
+			name:   "three zones",
+			parent: "2600:1f14:e08:7f00::/56",
+			count:  3,
+			expect: []string{
+				"2600:1f14:e08:7f00::/64",
+				"2600:1f14:e08:7f01::/64",
+				"2600:1f14:e08:7f02::/64",
+			},
+		},
+		{
+			name:      "not IPv6",
+			parent:    "10.0.0.0/16",
+			count:     2,
+			expectErr: "not an IPv6 CIDR",
+		},
+		{
+			name:      "exhausted",
+			parent:    "2600:1f14:e08:7f00::/56",
+			count:     300,
+			expectErr: "only 256 available",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SplitIntoSubnetsIPv6(tc.parent, tc.count)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			gotStr := make([]string, len(got))
+			for i, n := range got {
+				gotStr[i] = n.String()
+			}
+			assert.Equal(t, tc.expect, gotStr)
+		})
+	}
+}
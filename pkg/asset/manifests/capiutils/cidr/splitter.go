@@ -0,0 +1,119 @@
+package cidr
+
+import (
+	"fmt"
+	"net"
+)
+
+// Splitter carves subnet CIDR blocks out of a parent VPC CIDR. Implementations
+// decide how address space is distributed across the requested tiers.
+type Splitter interface {
+	// Split returns, in the same order as tiers, the list of CIDR blocks
+	// allocated to each tier.
+	Split(parentCIDR string, tiers []Tier) ([][]*net.IPNet, error)
+}
+
+// Tier describes one group of same-sized subnets to allocate out of the
+// parent CIDR, for example "private subnets, one per zone".
+type Tier struct {
+	// Name identifies the tier for error messages, e.g. "private", "public", "edge".
+	Name string
+	// Count is the number of subnets to allocate for this tier.
+	Count int
+	// PrefixLength is the prefix length each subnet in this tier should have.
+	// Only used by splitters that support explicit per-tier masks (ByMask).
+	PrefixLength int
+}
+
+// EqualSplitter divides the parent CIDR into equally sized, power-of-two
+// blocks, one per requested subnet across all tiers. This is the splitting
+// strategy the installer has always used via SplitIntoSubnetsIPv4.
+type EqualSplitter struct{}
+
+// Split implements Splitter.
+func (EqualSplitter) Split(parentCIDR string, tiers []Tier) ([][]*net.IPNet, error) {
+	total := 0
+	for _, tier := range tiers {
+		total += tier.Count
+	}
+
+	all, err := SplitIntoSubnetsIPv4(parentCIDR, total)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]*net.IPNet, len(tiers))
+	offset := 0
+	for i, tier := range tiers {
+		result[i] = all[offset : offset+tier.Count]
+		offset += tier.Count
+	}
+	return result, nil
+}
+
+// ByMaskSplitter allocates each tier's subnets sequentially out of the
+// parent CIDR using the tier's explicit PrefixLength, instead of dividing
+// the parent into equally sized blocks. This wastes less address space when
+// tiers need very different subnet sizes (e.g. large private subnets and
+// small public ones).
+type ByMaskSplitter struct{}
+
+// Split implements Splitter. It walks the tiers in the order given,
+// allocating PrefixLength-sized blocks from the lowest unused address of
+// the parent CIDR, and fails if a tier's blocks would overlap an already
+// allocated block or exhaust the parent CIDR. Because allocation always
+// starts from the lowest free address, repeated calls with the same parent
+// and tiers are deterministic: appending tiers, or raising the last tier's
+// Count, keeps existing allocations, while raising an earlier tier's Count
+// shifts the blocks of every later tier.
+func (ByMaskSplitter) Split(parentCIDR string, tiers []Tier) ([][]*net.IPNet, error) {
+	_, parent, err := net.ParseCIDR(parentCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse parent CIDR %q: %w", parentCIDR, err)
+	}
+	if parent.IP.To4() == nil {
+		return nil, fmt.Errorf("ByMaskSplitter only supports IPv4 parent CIDRs, got %q", parentCIDR)
+	}
+	const bits = 32
+	parentOnes, _ := parent.Mask.Size()
+
+	cursor := ipToUint(parent.IP, bits)
+	parentEnd := cursor + (uint64(1) << uint(bits-parentOnes))
+
+	result := make([][]*net.IPNet, len(tiers))
+	for i, tier := range tiers {
+		if tier.PrefixLength < parentOnes {
+			return nil, fmt.Errorf("tier %q prefix /%d is wider than the parent CIDR /%d", tier.Name, tier.PrefixLength, parentOnes)
+		}
+		blockSize := uint64(1) << uint(bits-tier.PrefixLength)
+
+		// Align the cursor up to a boundary for this tier's block size, so
+		// blocks never straddle a parent-unaligned address.
+		if rem := cursor % blockSize; rem != 0 {
+			cursor += blockSize - rem
+		}
+
+		blocks := make([]*net.IPNet, 0, tier.Count)
+		for n := 0; n < tier.Count; n++ {
+			if cursor+blockSize > parentEnd {
+				return nil, fmt.Errorf("unable to allocate %d subnet(s) of /%d for tier %q: parent CIDR %q exhausted", tier.Count, tier.PrefixLength, tier.Name, parentCIDR)
+			}
+			blocks = append(blocks, &net.IPNet{
+				IP:   uint64ToIP(cursor, bits),
+				Mask: net.CIDRMask(tier.PrefixLength, bits),
+			})
+			cursor += blockSize
+		}
+		result[i] = blocks
+	}
+	return result, nil
+}
+
+func ipToUint(ip net.IP, bits int) uint64 {
+	ip4 := ip.To4()
+	return uint64(ip4[0])<<24 | uint64(ip4[1])<<16 | uint64(ip4[2])<<8 | uint64(ip4[3])
+}
+
+func uint64ToIP(v uint64, bits int) net.IP {
+	return net.IPv4(byte(v>>24), byte(v>>16), byte(v>>8), byte(v)).To4()
+}
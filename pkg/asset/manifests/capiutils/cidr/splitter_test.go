@@ -0,0 +1,37 @@
+package cidr
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByMaskSplitter(t *testing.T) {
+	tiers := []Tier{
+		{Name: "private", Count: 2, PrefixLength: 24},
+		{Name: "public", Count: 2, PrefixLength: 27},
+	}
+
+	blocks, err := (ByMaskSplitter{}).Split("10.0.0.0/16", tiers)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 2)
+	assert.Equal(t, []string{"10.0.0.0/24", "10.0.1.0/24"}, cidrStrings(blocks[0]))
+	assert.Equal(t, []string{"10.0.2.0/27", "10.0.2.32/27"}, cidrStrings(blocks[1]))
+}
+
+func TestByMaskSplitterExhausted(t *testing.T) {
+	tiers := []Tier{
+		{Name: "private", Count: 1000, PrefixLength: 28},
+	}
+	_, err := (ByMaskSplitter{}).Split("10.0.0.0/24", tiers)
+	assert.ErrorContains(t, err, "exhausted")
+}
+
+func cidrStrings(nets []*net.IPNet) []string {
+	out := make([]string, len(nets))
+	for i, n := range nets {
+		out[i] = n.String()
+	}
+	return out
+}
@@ -5,4 +5,12 @@ const (
 	RegularZoneType = "availability-zone"
 	// LocalZoneType is the type of Local zone placed on the metropolitan areas.
 	LocalZoneType = "local-zone"
+	// WavelengthZoneType is the type of Wavelength zone embedded in telecommunication
+	// providers' networks, reached over a carrier gateway instead of an internet gateway.
+	WavelengthZoneType = "wavelength-zone"
+	// OutpostZoneType is the type reported for an AWS Outpost, a rack of AWS
+	// infrastructure installed in a customer's own data center. Unlike Local
+	// Zones and Wavelength Zones, an Outpost shares its parent region's zone
+	// name and can only be identified by its Outpost ARN, not by zone name.
+	OutpostZoneType = "outposts"
 )
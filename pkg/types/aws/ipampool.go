@@ -0,0 +1,19 @@
+package aws
+
+// IPAMPool lets the VPC CIDR for a managed VPC be allocated out of an
+// existing AWS VPC IPAM pool instead of a fixed CIDR block supplied in
+// install-config.yaml.
+type IPAMPool struct {
+	// PoolID is the ID of the IPAM pool to allocate the VPC CIDR from.
+	PoolID string `json:"poolID"`
+
+	// NetmaskLength is the prefix length of the CIDR to request from the
+	// pool, for example 16 for a /16.
+	NetmaskLength int32 `json:"netmaskLength"`
+
+	// SecondaryPool optionally names a second IPAM pool. When set, edge
+	// (Local Zone or Wavelength Zone) subnets reference this pool instead of
+	// the primary one; the VPC CIDR is always claimed from the primary pool.
+	// +optional
+	SecondaryPool *IPAMPool `json:"secondaryPool,omitempty"`
+}
@@ -0,0 +1,242 @@
+package aws
+
+// EC2Metadata configures the EC2 instance metadata service.
+type EC2Metadata struct {
+	// Authentication determines whether or not the EC2 Instance Metadata API
+	// requires session tokens. Authentication can be set to Optional or Required.
+	Authentication string `json:"authentication,omitempty"`
+}
+
+// EC2RootVolume defines the storage for an EC2 instance.
+type EC2RootVolume struct {
+	// IOPS defines the iops for the storage.
+	IOPS int `json:"iops"`
+	// Size defines the size of the storage.
+	Size int `json:"size"`
+	// Type defines the type of the storage.
+	Type string `json:"type"`
+	// KMSKeyARN is the ARN of the KMS key used to encrypt the root volume.
+	KMSKeyARN string `json:"kmsKeyARN,omitempty"`
+}
+
+// PlacementGroupStrategy describes the placement strategy of an existing EC2
+// placement group referenced by PlacementGroupName.
+type PlacementGroupStrategy string
+
+const (
+	// PlacementGroupStrategyPartition is the "partition" EC2 placement group strategy.
+	PlacementGroupStrategyPartition PlacementGroupStrategy = "partition"
+	// PlacementGroupStrategySpread is the "spread" EC2 placement group strategy.
+	PlacementGroupStrategySpread PlacementGroupStrategy = "spread"
+)
+
+// SpreadPolicy controls how a compute pool's replicas are distributed
+// across the zones it was assigned, whether from an explicit Zones list or
+// a resolved Placement expression.
+type SpreadPolicy string
+
+const (
+	// SpreadPack places every replica in a single zone, the long-standing
+	// default behavior.
+	SpreadPack SpreadPolicy = "Pack"
+	// SpreadBalanced spreads replicas round-robin across min(replicas,
+	// len(zones)) zones, starting from a zone chosen deterministically from
+	// the cluster name so repeated defaulting picks the same zones.
+	SpreadBalanced SpreadPolicy = "Balanced"
+	// SpreadMaxAZ spreads replicas round-robin across as many zones as
+	// there are replicas for, up to every assigned zone, always starting
+	// from the first zone so the assignment does not depend on the cluster
+	// name.
+	SpreadMaxAZ SpreadPolicy = "MaxAZ"
+)
+
+// ZoneDistributionMode selects how ZoneDistribution.Weights is interpreted.
+type ZoneDistributionMode string
+
+const (
+	// ZoneDistributionExplicit treats each entry in Weights as the exact
+	// replica count to place in that zone. The entries must sum to the
+	// pool's Replicas. This is the default mode.
+	ZoneDistributionExplicit ZoneDistributionMode = "Explicit"
+	// ZoneDistributionWeighted treats each entry in Weights as a relative
+	// weight; replicas are divided proportionally across the named zones,
+	// with the remainder assigned to the zones with the largest fractional
+	// share. A zone with no entry in Weights gets the default weight of 1.
+	ZoneDistributionWeighted ZoneDistributionMode = "Weighted"
+)
+
+// ZoneDistribution overrides the default even, round-robin distribution of a
+// pool's Replicas across its Zones with either explicit or weighted per-zone
+// replica counts, or a single pinned zone for stateful workloads that must
+// co-locate with infrastructure, like an EBS volume, already in that zone.
+type ZoneDistribution struct {
+	// Weights names, for each zone that should receive a non-default share
+	// of replicas, either its exact replica count (Mode Explicit) or its
+	// relative weight (Mode Weighted). Every key must also appear in the
+	// pool's Zones.
+	// +optional
+	Weights map[string]int32 `json:"weights,omitempty"`
+
+	// Mode selects how Weights is interpreted. Defaults to
+	// ZoneDistributionExplicit.
+	// +optional
+	Mode ZoneDistributionMode `json:"mode,omitempty"`
+
+	// Pinned, when set, forces every replica of the pool into this single
+	// zone, which must also appear in the pool's Zones. Pinned takes
+	// precedence over Weights and Mode.
+	// +optional
+	Pinned string `json:"pinned,omitempty"`
+}
+
+// MachinePoolAutoscaling bounds cluster-autoscaler's scaling of a pool's
+// MachineSets, letting replica counts grow and shrink with workload demand
+// instead of staying pinned at the pool's configured Replicas.
+type MachinePoolAutoscaling struct {
+	// MinReplicas is the lowest replica count cluster-autoscaler will scale
+	// this pool's MachineSets down to.
+	MinReplicas int32 `json:"minReplicas"`
+
+	// MaxReplicas is the highest replica count cluster-autoscaler will
+	// scale this pool's MachineSets up to.
+	MaxReplicas int32 `json:"maxReplicas"`
+}
+
+// MachinePool stores the configuration for a machine pool installed on AWS.
+type MachinePool struct {
+	// Zones is list of availability zones that can be used.
+	Zones []string `json:"zones,omitempty"`
+
+	// InstanceType defines the ec2 instance type.
+	InstanceType string `json:"type"`
+
+	// AMIID is the AMI that should be used to boot machines for the pool.
+	AMIID string `json:"amiID,omitempty"`
+
+	// EC2RootVolume defines the storage for ec2 instance.
+	EC2RootVolume EC2RootVolume `json:"rootVolume"`
+
+	// EC2Metadata defines metadata service interaction options for EC2 instance.
+	EC2Metadata EC2Metadata `json:"metadataService,omitempty"`
+
+	// PlacementGroupName names a pre-existing EC2 placement group that
+	// instances in this pool are launched into. The group's strategy is
+	// discovered from AWS.
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// PlacementGroupPartition selects which partition, 1 through 7, to launch
+	// instances into when PlacementGroupName refers to a placement group
+	// with the "partition" strategy. It is invalid to set this field when
+	// the referenced group uses the "spread" strategy.
+	PlacementGroupPartition *int32 `json:"placementGroupPartition,omitempty"`
+
+	// MachineNetworkIndex pins this pool's zones to the IPv4
+	// Networking.MachineNetwork entry at this index (index 0 is the primary).
+	// This is currently only consulted for the edge compute pool, letting
+	// Local Zone or Wavelength Zone subnets be carved out of a secondary VPC
+	// CIDR block rather than the primary one. When unset, the edge pool uses
+	// the first secondary IPv4 entry if one is configured, else the primary.
+	// +optional
+	MachineNetworkIndex *int `json:"machineNetworkIndex,omitempty"`
+
+	// Placement is a declarative placement expression resolved against the
+	// discovered zone catalog to select this pool's zones, e.g.
+	// "region(us-east-1) & !az(us-east-1e)". It is an alternative to Zones;
+	// when both are set, Placement takes precedence.
+	// +optional
+	Placement string `json:"placement,omitempty"`
+
+	// SpreadPolicy controls how this pool's replicas are distributed across
+	// its assigned zones. Defaults to SpreadPack.
+	// +optional
+	SpreadPolicy SpreadPolicy `json:"spreadPolicy,omitempty"`
+
+	// ZoneDistribution overrides the default even distribution of this
+	// pool's replicas across its Zones with explicit per-zone counts,
+	// weights, or a single pinned zone.
+	// +optional
+	ZoneDistribution *ZoneDistribution `json:"zoneDistribution,omitempty"`
+
+	// Autoscaling, when set, generates a MachineAutoscaler for each of this
+	// pool's MachineSets instead of leaving their replica counts fixed at
+	// Replicas.
+	// +optional
+	Autoscaling *MachinePoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// SubnetSelection constrains which subnet topology, within each of this
+	// pool's zones, its instances may be placed into in a BYO VPC: "public",
+	// "private", or "all". Defaults to "all" (no filtering, the
+	// long-standing behavior) when unset. This is scoped to a single pool,
+	// unlike the cluster-wide OPENSHIFT_INSTALL_AWS_PUBLIC_ONLY escape
+	// hatch, so e.g. NAT-less workers can be mixed with private
+	// control-plane nodes in the same BYO VPC.
+	// +optional
+	SubnetSelection SubnetSelection `json:"subnetSelection,omitempty"`
+}
+
+// Set sets the values from `required` to `a`.
+func (a *MachinePool) Set(required *MachinePool) {
+	if required == nil || a == nil {
+		return
+	}
+
+	if len(required.Zones) > 0 {
+		a.Zones = required.Zones
+	}
+
+	if required.InstanceType != "" {
+		a.InstanceType = required.InstanceType
+	}
+
+	if required.AMIID != "" {
+		a.AMIID = required.AMIID
+	}
+
+	if required.EC2RootVolume.IOPS != 0 {
+		a.EC2RootVolume.IOPS = required.EC2RootVolume.IOPS
+	}
+
+	if required.EC2RootVolume.Size != 0 {
+		a.EC2RootVolume.Size = required.EC2RootVolume.Size
+	}
+
+	if required.EC2RootVolume.Type != "" {
+		a.EC2RootVolume.Type = required.EC2RootVolume.Type
+	}
+
+	if required.EC2RootVolume.KMSKeyARN != "" {
+		a.EC2RootVolume.KMSKeyARN = required.EC2RootVolume.KMSKeyARN
+	}
+
+	if required.PlacementGroupName != "" {
+		a.PlacementGroupName = required.PlacementGroupName
+	}
+
+	if required.PlacementGroupPartition != nil {
+		a.PlacementGroupPartition = required.PlacementGroupPartition
+	}
+
+	if required.MachineNetworkIndex != nil {
+		a.MachineNetworkIndex = required.MachineNetworkIndex
+	}
+
+	if required.Placement != "" {
+		a.Placement = required.Placement
+	}
+
+	if required.SpreadPolicy != "" {
+		a.SpreadPolicy = required.SpreadPolicy
+	}
+
+	if required.ZoneDistribution != nil {
+		a.ZoneDistribution = required.ZoneDistribution
+	}
+
+	if required.Autoscaling != nil {
+		a.Autoscaling = required.Autoscaling
+	}
+
+	if required.SubnetSelection != "" {
+		a.SubnetSelection = required.SubnetSelection
+	}
+}
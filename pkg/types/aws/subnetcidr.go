@@ -0,0 +1,65 @@
+package aws
+
+// SubnetCIDRAllocationStrategy selects how the installer carves per-zone
+// subnet CIDRs out of the managed VPC's CIDR block.
+type SubnetCIDRAllocationStrategy string
+
+const (
+	// SubnetCIDRAllocationStrategyEqual splits the VPC CIDR into equally
+	// sized blocks across all requested subnets. This is the legacy,
+	// always-on behavior.
+	SubnetCIDRAllocationStrategyEqual SubnetCIDRAllocationStrategy = "Equal"
+
+	// SubnetCIDRAllocationStrategyByMask allocates subnets sequentially out
+	// of the VPC CIDR using explicit per-tier prefix lengths.
+	SubnetCIDRAllocationStrategyByMask SubnetCIDRAllocationStrategy = "ByMask"
+
+	// SubnetCIDRAllocationStrategyExplicit allocates subnets using explicit,
+	// user-supplied CIDR blocks keyed by zone name, instead of computing
+	// them. Zones without a matching entry fail validation.
+	SubnetCIDRAllocationStrategyExplicit SubnetCIDRAllocationStrategy = "Explicit"
+)
+
+// SubnetCIDRAllocation configures how managed-VPC subnet CIDRs are computed.
+type SubnetCIDRAllocation struct {
+	// Strategy selects the splitting algorithm. Defaults to Equal.
+	// +optional
+	Strategy SubnetCIDRAllocationStrategy `json:"strategy,omitempty"`
+
+	// PrivateSubnetPrefix is the prefix length used for each private subnet
+	// when Strategy is ByMask.
+	// +optional
+	PrivateSubnetPrefix int32 `json:"privateSubnetPrefix,omitempty"`
+
+	// PublicSubnetPrefix is the prefix length used for each public subnet
+	// when Strategy is ByMask.
+	// +optional
+	PublicSubnetPrefix int32 `json:"publicSubnetPrefix,omitempty"`
+
+	// EdgeSubnetPrefix is the prefix length used for each edge (Local Zone
+	// or Wavelength Zone) subnet when Strategy is ByMask.
+	// +optional
+	EdgeSubnetPrefix int32 `json:"edgeSubnetPrefix,omitempty"`
+
+	// PrivateSubnetCIDRs maps a zone name to the explicit CIDR block used
+	// for that zone's private subnet when Strategy is Explicit.
+	// +optional
+	PrivateSubnetCIDRs map[string]string `json:"privateSubnetCIDRs,omitempty"`
+
+	// PublicSubnetCIDRs maps a zone name to the explicit CIDR block used
+	// for that zone's public subnet when Strategy is Explicit.
+	// +optional
+	PublicSubnetCIDRs map[string]string `json:"publicSubnetCIDRs,omitempty"`
+
+	// EdgeSubnetCIDRs maps a zone name to the explicit CIDR block used for
+	// that zone's edge (Local Zone or Wavelength Zone) private subnet when
+	// Strategy is Explicit.
+	// +optional
+	EdgeSubnetCIDRs map[string]string `json:"edgeSubnetCIDRs,omitempty"`
+
+	// EdgePublicSubnetCIDRs maps a zone name to the explicit CIDR block used
+	// for that zone's edge (Local Zone or Wavelength Zone) public subnet
+	// when Strategy is Explicit.
+	// +optional
+	EdgePublicSubnetCIDRs map[string]string `json:"edgePublicSubnetCIDRs,omitempty"`
+}
@@ -0,0 +1,14 @@
+package aws
+
+// SubnetSelection constrains which subnet topology, within an Availability
+// Zone, a machine pool's instances may be placed into.
+type SubnetSelection string
+
+const (
+	// SubnetSelectionPublic restricts the pool to public subnets in its zones.
+	SubnetSelectionPublic SubnetSelection = "public"
+	// SubnetSelectionPrivate restricts the pool to private subnets in its zones.
+	SubnetSelectionPrivate SubnetSelection = "private"
+	// SubnetSelectionAll allows the pool to use any subnet discovered for its zones.
+	SubnetSelectionAll SubnetSelection = "all"
+)